@@ -0,0 +1,82 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Размер пакета испытаний по умолчанию для адаптивного метода
+const defaultBatchSize = 10000
+
+// StopRule - правило остановки адаптивного метода Монте-Карло.
+// Испытания проводятся пакетами, пока не выполнены все заданные
+// (ненулевые) условия точности или не исчерпан бюджет испытаний.
+type StopRule struct {
+	RelErr    float64 // Целевая относительная погрешность: полуширина ДИ / |оценка|
+	HalfWidth float64 // Целевая полуширина доверительного интервала
+	Level     float64 // Уровень доверия (по умолчанию DefaultLevel)
+	Budget    int     // Максимальное количество испытаний
+	Batch     int     // Размер пакета (по умолчанию defaultBatchSize)
+}
+
+// AdaptiveResult - результат адаптивного метода; фактическое количество
+// испытаний хранится в поле ExpNmb
+type AdaptiveResult struct {
+	MCResult
+	Batches int  // Количество выполненных пакетов
+	Reached bool // Достигнута ли заданная точность
+}
+
+// satisfied - выполнены ли условия точности для текущего результата
+func (rule StopRule) satisfied(res MCResult) bool {
+	if res.ExpNmb < 2 {
+		return false
+	}
+	if rule.HalfWidth > 0 && res.HalfWidth() > rule.HalfWidth {
+		return false
+	}
+	if rule.RelErr > 0 && res.HalfWidth() > rule.RelErr*math.Abs(res.Estimate) {
+		return false
+	}
+	return rule.HalfWidth > 0 || rule.RelErr > 0
+}
+
+// RunAdaptive - последовательный метод Монте-Карло: испытания sampler
+// проводятся пакетами по rule.Batch, после каждого пакета строится
+// доверительный интервал и проверяется правило остановки
+func RunAdaptive(sampler Sampler, rule StopRule, rng *rand.Rand) AdaptiveResult {
+	if rule.Level <= 0 {
+		rule.Level = DefaultLevel
+	}
+	if rule.Batch <= 0 {
+		rule.Batch = defaultBatchSize
+	}
+
+	var acc Accumulator
+	var res AdaptiveResult
+	for acc.Count() < rule.Budget {
+		n := min(rule.Batch, rule.Budget-acc.Count())
+		for i := 0; i < n; i++ {
+			acc.Add(sampler(rng))
+		}
+		res.Batches++
+		res.MCResult = acc.Result(rule.Level)
+		if rule.satisfied(res.MCResult) {
+			res.Reached = true
+			break
+		}
+	}
+	return res
+}
+
+// CALC_PI_ADAPTIVE - расчет числа Пи до достижения заданной точности
+func CALC_PI_ADAPTIVE(x0, y0, r0 float64, rule StopRule, seed int64) AdaptiveResult {
+	return RunAdaptive(PiSampler(x0, y0, r0), rule, rand.New(rand.NewSource(seed)))
+}
+
+// CALC_INTEGRAL_ADAPTIVE - интегрирование методом "попал/не попал"
+// с автоматическим охватывающим прямоугольником до достижения
+// заданной точности
+func CALC_INTEGRAL_ADAPTIVE(a, b float64, f func(float64) float64, rule StopRule, seed int64) AdaptiveResult {
+	return RunAdaptive(HitOrMissSampler(a, b, f, FindEnvelope(a, b, f)), rule, rand.New(rand.NewSource(seed)))
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+)
+
+// proportionResult - оценка g(p) по доле успешных испытаний p = hits/n.
+// Дисперсия одного испытания находится дельта-методом:
+// D[g(p)] ≈ g'(p)^2 p(1-p), поэтому результаты разных оценок числа Пи
+// сравнимы с CALC_PI_STATS по дисперсии на одно испытание.
+func proportionResult(hits, n int, g, dg func(p float64) float64, level float64) MCResult {
+	p := float64(hits) / float64(n)
+	d := dg(p)
+	return newMCResult(g(p), d*d*p*(1-p), n, level)
+}
+
+// randomDirection - случайное направление (cos θ, sin θ), θ ~ U[0, 2π),
+// полученное отбором точки в единичном круге, чтобы не использовать
+// число Пи при его же оценке
+func randomDirection() (c, s float64) {
+	for {
+		u := 2*rand.Float64() - 1
+		v := 2*rand.Float64() - 1
+		r2 := u*u + v*v
+		if r2 > 0 && r2 <= 1 {
+			r := math.Sqrt(r2)
+			return u / r, v / r
+		}
+	}
+}
+
+// BuffonNeedle - задача Бюффона: игла длины l бросается на плоскость,
+// расчерченную параллельными прямыми с шагом t (l <= t). Вероятность
+// пересечения прямой P = 2l/(πt), откуда π = 2l/(tP).
+func BuffonNeedle(l, t float64, expNmb int, level float64) MCResult {
+	hits := 0
+	for i := 0; i < expNmb; i++ {
+		// Расстояние от центра иглы до ближайшей прямой и угол с прямыми
+		y := t / 2 * rand.Float64()
+		_, s := randomDirection()
+		if y <= l/2*math.Abs(s) {
+			hits++
+		}
+	}
+	c := 2 * l / t
+	return proportionResult(hits, expNmb,
+		func(p float64) float64 { return c / p },
+		func(p float64) float64 { return -c / (p * p) },
+		level)
+}
+
+// BuffonLaplace - задача Бюффона - Лапласа: игла длины l бросается на
+// сетку прямоугольников a x b (l <= min(a, b)). Вероятность пересечения
+// хотя бы одной линии сетки P = (2l(a+b) - l^2)/(πab).
+func BuffonLaplace(l, a, b float64, expNmb int, level float64) MCResult {
+	hits := 0
+	for i := 0; i < expNmb; i++ {
+		x := a * rand.Float64()
+		y := b * rand.Float64()
+		c, s := randomDirection()
+		dx, dy := l/2*c, l/2*s
+		// Концы иглы лежат в разных полосах сетки хотя бы по одной оси
+		if math.Floor((x-dx)/a) != math.Floor((x+dx)/a) || math.Floor((y-dy)/b) != math.Floor((y+dy)/b) {
+			hits++
+		}
+	}
+	k := (2*l*(a+b) - l*l) / (a * b)
+	return proportionResult(hits, expNmb,
+		func(p float64) float64 { return k / p },
+		func(p float64) float64 { return -k / (p * p) },
+		level)
+}
+
+// gcd - наибольший общий делитель (алгоритм Евклида)
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// Верхняя граница случайных целых чисел в CoprimePi
+const coprimeMax = 1 << 40
+
+// CoprimePi - оценка по вероятности того, что два случайных целых числа
+// взаимно просты: P = 6/π^2, откуда π = sqrt(6/P). Числа выбираются
+// равномерно из [1, coprimeMax], смещение от конечности диапазона
+// пренебрежимо мало по сравнению со статистической погрешностью.
+func CoprimePi(expNmb int, level float64) MCResult {
+	hits := 0
+	for i := 0; i < expNmb; i++ {
+		if gcd(rand.Int63n(coprimeMax)+1, rand.Int63n(coprimeMax)+1) == 1 {
+			hits++
+		}
+	}
+	return proportionResult(hits, expNmb,
+		func(p float64) float64 { return math.Sqrt(6 / p) },
+		func(p float64) float64 { return -0.5 * math.Sqrt(6/(p*p*p)) },
+		level)
+}
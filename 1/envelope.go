@@ -0,0 +1,132 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Envelope - охватывающий прямоугольник для метода "попал/не попал":
+// по оси X совпадает с [a, b], по оси Y - с [YMin, YMax]
+type Envelope struct {
+	YMin float64
+	YMax float64
+}
+
+// HitOrMissResult - результат интегрирования методом "попал/не попал"
+// со знаком: точки между осью X и графиком над осью учитываются
+// как положительные попадания, под осью - как отрицательные
+type HitOrMissResult struct {
+	MCResult              // Значение интеграла (площадь со знаком) и его погрешность
+	PositiveHits int      // Попадания между осью X и графиком при f(x) > 0
+	NegativeHits int      // Попадания между графиком и осью X при f(x) < 0
+	Envelope     Envelope // Использованный охватывающий прямоугольник
+}
+
+// Количество точек грубого просмотра функции при поиске её диапазона
+const envelopeScanPoints = 1000
+
+// Относительный запас, на который расширяется найденный диапазон функции:
+// уточнение экстремумов численное, поэтому границы берутся с запасом
+const envelopeMargin = 0.01
+
+// FindEnvelope - поиск диапазона значений функции f на [a, b]:
+// грубый просмотр по равномерной сетке, затем уточнение минимума и
+// максимума методом золотого сечения в окрестности лучших узлов сетки.
+// Ось X (y = 0) всегда попадает внутрь прямоугольника, чтобы площадь
+// со знаком считалась относительно неё.
+func FindEnvelope(a, b float64, f func(float64) float64) Envelope {
+	h := (b - a) / float64(envelopeScanPoints)
+
+	iMin, iMax := 0, 0
+	fMin, fMax := f(a), f(a)
+	for i := 1; i <= envelopeScanPoints; i++ {
+		y := f(a + float64(i)*h)
+		if y < fMin {
+			fMin, iMin = y, i
+		}
+		if y > fMax {
+			fMax, iMax = y, i
+		}
+	}
+
+	// Уточняем экстремумы на отрезке из двух соседних шагов сетки
+	lo := math.Max(a, a+float64(iMin-1)*h)
+	hi := math.Min(b, a+float64(iMin+1)*h)
+	fMin = math.Min(fMin, f(goldenSection(lo, hi, f)))
+
+	neg := func(x float64) float64 { return -f(x) }
+	lo = math.Max(a, a+float64(iMax-1)*h)
+	hi = math.Min(b, a+float64(iMax+1)*h)
+	fMax = math.Max(fMax, f(goldenSection(lo, hi, neg)))
+
+	yMin := math.Min(fMin, 0)
+	yMax := math.Max(fMax, 0)
+	margin := envelopeMargin * (yMax - yMin)
+
+	env := Envelope{YMin: yMin, YMax: yMax}
+	if yMin < 0 {
+		env.YMin -= margin
+	}
+	if yMax > 0 {
+		env.YMax += margin
+	}
+	return env
+}
+
+// goldenSection - поиск точки минимума унимодальной функции на [lo, hi]
+// методом золотого сечения
+func goldenSection(lo, hi float64, f func(float64) float64) float64 {
+	const tol = 1e-10
+	invPhi := (math.Sqrt(5) - 1) / 2
+
+	x1 := hi - invPhi*(hi-lo)
+	x2 := lo + invPhi*(hi-lo)
+	f1, f2 := f(x1), f(x2)
+	for hi-lo > tol*(1+math.Abs(lo)+math.Abs(hi)) {
+		if f1 < f2 {
+			hi, x2, f2 = x2, x1, f1
+			x1 = hi - invPhi*(hi-lo)
+			f1 = f(x1)
+		} else {
+			lo, x1, f1 = x1, x2, f2
+			x2 = lo + invPhi*(hi-lo)
+			f2 = f(x2)
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// HitOrMissIntegral - интегрирование методом "попал/не попал" в заданном
+// охватывающем прямоугольнике. Прямоугольник должен содержать график функции
+// на [a, b] и ось X; для автоматического выбора границ используется FindEnvelope.
+func HitOrMissIntegral(a, b float64, f func(float64) float64, env Envelope, expNmb int, level float64) HitOrMissResult {
+	res := HitOrMissResult{Envelope: env}
+	var acc Accumulator
+
+	area := (b - a) * (env.YMax - env.YMin)
+	var x, y, fx float64
+	for i := 0; i < expNmb; i++ {
+		x = a + (b-a)*rand.Float64()
+		y = env.YMin + (env.YMax-env.YMin)*rand.Float64()
+		fx = f(x)
+		if y >= 0 && y < fx {
+			res.PositiveHits++
+			acc.Add(area)
+		} else if y < 0 && y > fx {
+			res.NegativeHits++
+			acc.Add(-area)
+		} else {
+			acc.Add(0)
+		}
+	}
+
+	res.MCResult = acc.Result(level)
+	return res
+}
+
+// CALC_INTEGRAL_AUTO - вариант CALC_INTEGRAL с автоматическим поиском
+// охватывающего прямоугольника, пригодный для немонотонных и
+// знакопеременных функций
+func CALC_INTEGRAL_AUTO(a, b float64, f func(float64) float64, expNmb int) HitOrMissResult {
+	return HitOrMissIntegral(a, b, f, FindEnvelope(a, b, f), expNmb, DefaultLevel)
+}
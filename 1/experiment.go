@@ -0,0 +1,264 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Estimator - оценка искомой величины по expNmb испытаниям
+type Estimator func(expNmb int) float64
+
+// StudyRow - результаты серий экспериментов при одном значении N
+type StudyRow struct {
+	N          int       `json:"n"`
+	Values     []float64 `json:"values"`       // Оценки в каждой серии
+	Mean       float64   `json:"mean"`         // Среднее по сериям
+	MeanRelErr float64   `json:"mean_rel_err"` // Относительная погрешность среднего по сериям
+	RMSRelErr  float64   `json:"rms_rel_err"`  // Среднеквадратичная относительная погрешность серий
+	StdDev     float64   `json:"std_dev"`      // Выборочное СКО оценок
+	Min        float64   `json:"min"`
+	Max        float64   `json:"max"`
+}
+
+// ConvergenceStudy - исследование сходимости оценки при росте N
+type ConvergenceStudy struct {
+	Name         string     `json:"name"`
+	Exact        float64    `json:"exact"`        // Точное значение
+	Replications int        `json:"replications"` // Количество серий
+	Rows         []StudyRow `json:"rows"`
+	Slope        float64    `json:"slope"`     // Наклон log10(погрешность) от log10(N)
+	Intercept    float64    `json:"intercept"` // Свободный член той же прямой
+}
+
+// PowerGrid - сетка N = 10^from, ..., 10^to
+func PowerGrid(from, to int) ([]int, error) {
+	if from < 0 || to < from {
+		return nil, fmt.Errorf("некорректные показатели сетки: от 10^%d до 10^%d", from, to)
+	}
+	grid := make([]int, 0, to-from+1)
+	for i := from; i <= to; i++ {
+		grid = append(grid, int(math.Pow(10, float64(i))))
+	}
+	return grid, nil
+}
+
+// RunConvergenceStudy - для каждого N из grid выполняется replications
+// серий оценки est, по ним считаются среднее, погрешности и разброс.
+// По среднеквадратичной погрешности строится регрессия в логарифмическом
+// масштабе, наклон которой равен порядку сходимости (-1/2 для метода
+// Монте-Карло).
+func RunConvergenceStudy(name string, est Estimator, exact float64, grid []int, replications int) ConvergenceStudy {
+	study := ConvergenceStudy{Name: name, Exact: exact, Replications: replications}
+
+	for _, n := range grid {
+		row := StudyRow{N: n, Values: make([]float64, replications)}
+		var acc Accumulator
+		var sqErr float64
+		for r := 0; r < replications; r++ {
+			v := est(n)
+			row.Values[r] = v
+			acc.Add(v)
+			relErr := (v - exact) / exact
+			sqErr += relErr * relErr
+		}
+		row.Mean = acc.Mean()
+		row.MeanRelErr = math.Abs((row.Mean - exact) / exact)
+		row.RMSRelErr = math.Sqrt(sqErr / float64(replications))
+		row.StdDev = math.Sqrt(acc.Variance())
+		row.Min, row.Max = row.Values[0], row.Values[0]
+		for _, v := range row.Values {
+			row.Min = math.Min(row.Min, v)
+			row.Max = math.Max(row.Max, v)
+		}
+		study.Rows = append(study.Rows, row)
+	}
+
+	study.Slope, study.Intercept = study.fitSlope()
+	return study
+}
+
+// fitSlope - метод наименьших квадратов для log10(RMSRelErr) от log10(N)
+func (s ConvergenceStudy) fitSlope() (slope, intercept float64) {
+	var n, sx, sy, sxx, sxy float64
+	for _, row := range s.Rows {
+		if row.RMSRelErr <= 0 {
+			continue
+		}
+		x := math.Log10(float64(row.N))
+		y := math.Log10(row.RMSRelErr)
+		n++
+		sx += x
+		sy += y
+		sxx += x * x
+		sxy += x * y
+	}
+	if n < 2 {
+		return 0, 0
+	}
+	slope = (n*sxy - sx*sy) / (n*sxx - sx*sx)
+	intercept = (sy - slope*sx) / n
+	return slope, intercept
+}
+
+// Print - вывод таблицы результатов исследования
+func (s ConvergenceStudy) Print() {
+	fmt.Printf("%s (точное значение %g, серий: %d)\n", s.Name, s.Exact, s.Replications)
+	fmt.Printf("%-12s %14s %14s %14s %14s %14s %14s\n",
+		"N", "Среднее", "Погр. ср.", "СКП серий", "СКО", "Минимум", "Максимум")
+	for _, row := range s.Rows {
+		fmt.Printf("%-12d %14.8f %14.3e %14.3e %14.3e %14.8f %14.8f\n",
+			row.N, row.Mean, row.MeanRelErr, row.RMSRelErr, row.StdDev, row.Min, row.Max)
+	}
+	fmt.Printf("Порядок сходимости: погрешность ~ N^(%.3f)\n", s.Slope)
+}
+
+// SaveCSV - сохранение таблицы результатов в CSV
+func (s ConvergenceStudy) SaveCSV(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	header := []string{"n", "mean", "mean_rel_err", "rms_rel_err", "std_dev", "min", "max"}
+	for r := 0; r < s.Replications; r++ {
+		header = append(header, fmt.Sprintf("seria_%d", r+1))
+	}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	format := func(v float64) string {
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	for _, row := range s.Rows {
+		record := []string{strconv.Itoa(row.N), format(row.Mean), format(row.MeanRelErr),
+			format(row.RMSRelErr), format(row.StdDev), format(row.Min), format(row.Max)}
+		for _, v := range row.Values {
+			record = append(record, format(v))
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// SaveJSON - сохранение результатов исследования в JSON
+func (s ConvergenceStudy) SaveJSON(filename string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0o644)
+}
+
+// SavePlot - график зависимости погрешности от N в логарифмическом
+// масштабе с подобранной прямой сходимости
+func (s ConvergenceStudy) SavePlot(filename string) error {
+	p := plot.New()
+	p.Title.Text = fmt.Sprintf("%s: сходимость, наклон %.3f", s.Name, s.Slope)
+	p.X.Label.Text = "Число экспериментов (N)"
+	p.Y.Label.Text = "Относительная погрешность"
+	p.X.Scale = plot.LogScale{}
+	p.Y.Scale = plot.LogScale{}
+	p.X.Tick.Marker = plot.LogTicks{}
+	p.Y.Tick.Marker = plot.LogTicks{}
+	p.Legend.Top = true
+
+	rmsPts := make(plotter.XYs, 0, len(s.Rows))
+	meanPts := make(plotter.XYs, 0, len(s.Rows))
+	fitPts := make(plotter.XYs, 0, len(s.Rows))
+	for _, row := range s.Rows {
+		x := float64(row.N)
+		if row.RMSRelErr > 0 {
+			rmsPts = append(rmsPts, plotter.XY{X: x, Y: row.RMSRelErr})
+		}
+		if row.MeanRelErr > 0 {
+			meanPts = append(meanPts, plotter.XY{X: x, Y: row.MeanRelErr})
+		}
+		fitPts = append(fitPts, plotter.XY{X: x, Y: math.Pow(10, s.Intercept+s.Slope*math.Log10(x))})
+	}
+
+	rms, err := plotter.NewScatter(rmsPts)
+	if err != nil {
+		return err
+	}
+	rms.GlyphStyle.Color = color{255, 0, 0}
+	rms.GlyphStyle.Radius = vg.Points(3)
+	rms.GlyphStyle.Shape = draw.CircleGlyph{}
+	p.Add(rms)
+	p.Legend.Add("СКП серий", rms)
+
+	if len(meanPts) > 0 {
+		mean, err := plotter.NewScatter(meanPts)
+		if err != nil {
+			return err
+		}
+		mean.GlyphStyle.Color = color{0, 128, 0}
+		mean.GlyphStyle.Radius = vg.Points(3)
+		mean.GlyphStyle.Shape = draw.TriangleGlyph{}
+		p.Add(mean)
+		p.Legend.Add("Погрешность среднего", mean)
+	}
+
+	fit, err := plotter.NewLine(fitPts)
+	if err != nil {
+		return err
+	}
+	fit.Color = color{0, 0, 255}
+	fit.Width = vg.Points(1)
+	fit.Dashes = []vg.Length{vg.Points(2), vg.Points(2)}
+	p.Add(fit)
+	p.Legend.Add(fmt.Sprintf("~N^(%.3f)", s.Slope), fit)
+
+	return p.Save(10*vg.Inch, 6*vg.Inch, filename)
+}
+
+// Save - сохранение графика, CSV и JSON с общим именем base
+func (s ConvergenceStudy) Save(base string) {
+	if err := s.SavePlot(base + ".png"); err != nil {
+		fmt.Printf("Ошибка сохранения графика: %v\n", err)
+	} else {
+		fmt.Printf("График сходимости сохранен: %s.png\n", base)
+	}
+	if err := s.SaveCSV(base + ".csv"); err != nil {
+		fmt.Printf("Ошибка сохранения CSV: %v\n", err)
+	} else {
+		fmt.Printf("Таблица сохранена: %s.csv\n", base)
+	}
+	if err := s.SaveJSON(base + ".json"); err != nil {
+		fmt.Printf("Ошибка сохранения JSON: %v\n", err)
+	} else {
+		fmt.Printf("Результаты сохранены: %s.json\n", base)
+	}
+}
+
+// Вспомогательная структура для цвета
+type color struct {
+	R, G, B uint8
+}
+
+// Метод для преобразования цвета в RGBA
+func (c color) RGBA() (r, g, b, a uint32) {
+	r = uint32(c.R)
+	r |= r << 8
+	g = uint32(c.G)
+	g |= g << 8
+	b = uint32(c.B)
+	b |= b << 8
+	a = uint32(255)
+	a |= a << 8
+	return
+}
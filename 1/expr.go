@@ -0,0 +1,407 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// ParseError - ошибка разбора выражения с позицией (номер символа, с 1)
+type ParseError struct {
+	Src string
+	Pos int
+	Msg string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("позиция %d: %s", e.Pos, e.Msg)
+}
+
+// Caret - исходное выражение и указатель на место ошибки под ним
+func (e *ParseError) Caret() string {
+	return e.Src + "\n" + strings.Repeat(" ", max(e.Pos-1, 0)) + "^"
+}
+
+// Функции, допустимые в выражениях
+var exprFuncs = map[string]func(float64) float64{
+	"sin":  math.Sin,
+	"cos":  math.Cos,
+	"exp":  math.Exp,
+	"log":  math.Log,
+	"sqrt": math.Sqrt,
+	"abs":  math.Abs,
+}
+
+// Именованные константы
+var exprConsts = map[string]float64{
+	"pi": math.Pi,
+	"e":  math.E,
+}
+
+// Expr - разобранное выражение, готовое к многократному вычислению
+type Expr struct {
+	src  string
+	vars []string                    // Имена переменных в порядке появления
+	eval func(env []float64) float64 // env[i] - значение переменной vars[i]
+}
+
+// Vars - имена переменных выражения
+func (e *Expr) Vars() []string {
+	return append([]string(nil), e.vars...)
+}
+
+// String - исходный текст выражения
+func (e *Expr) String() string {
+	return e.src
+}
+
+// Eval - значение выражения при заданных значениях переменных
+func (e *Expr) Eval(values map[string]float64) (float64, error) {
+	env := make([]float64, len(e.vars))
+	for i, name := range e.vars {
+		v, ok := values[name]
+		if !ok {
+			return 0, fmt.Errorf("не задано значение переменной %q", name)
+		}
+		env[i] = v
+	}
+	return e.eval(env), nil
+}
+
+// FuncN - функция n переменных для MultiIntegral: x[i] соответствует
+// переменной names[i]; в выражении не должно быть других переменных.
+// Массивы значений переменных берутся из пула, поэтому функцию можно
+// вызывать из нескольких горутин (например, в RunParallel).
+func (e *Expr) FuncN(names ...string) (func([]float64) float64, error) {
+	index := make([]int, len(e.vars))
+	for i, v := range e.vars {
+		index[i] = -1
+		for j, name := range names {
+			if v == name {
+				index[i] = j
+			}
+		}
+		if index[i] < 0 {
+			return nil, fmt.Errorf("переменная %q не входит в список %v", v, names)
+		}
+	}
+	pool := envPool(len(index))
+	return func(x []float64) float64 {
+		env := pool.Get().(*[]float64)
+		defer pool.Put(env)
+		for i, j := range index {
+			(*env)[i] = x[j]
+		}
+		return e.eval(*env)
+	}, nil
+}
+
+// Func1 - функция одной переменной name для CALC_INTEGRAL и построения
+// графиков. Как и FuncN, безопасна для одновременных вызовов.
+func (e *Expr) Func1(name string) (func(float64) float64, error) {
+	for _, v := range e.vars {
+		if v != name {
+			return nil, fmt.Errorf("переменная %q не является переменной интегрирования %q", v, name)
+		}
+	}
+	if len(e.vars) == 0 {
+		c := e.eval(nil)
+		return func(float64) float64 { return c }, nil
+	}
+	pool := envPool(1)
+	return func(x float64) float64 {
+		env := pool.Get().(*[]float64)
+		defer pool.Put(env)
+		(*env)[0] = x
+		return e.eval(*env)
+	}, nil
+}
+
+// envPool - пул массивов значений переменных длины n
+func envPool(n int) *sync.Pool {
+	return &sync.Pool{New: func() any {
+		env := make([]float64, n)
+		return &env
+	}}
+}
+
+// CompileFunc - разбор выражения от одной переменной x в функцию
+func CompileFunc(src string) (func(float64) float64, error) {
+	e, err := ParseExpr(src)
+	if err != nil {
+		return nil, err
+	}
+	return e.Func1("x")
+}
+
+// ParseExpr - разбор выражения. Грамматика (по возрастанию приоритета):
+//
+//	expr   = term {("+" | "-") term}
+//	term   = unary {("*" | "/") unary}
+//	unary  = ("+" | "-") unary | power
+//	power  = atom ["^" unary]          (правоассоциативно)
+//	atom   = число | константа | переменная | функция "(" expr ")" | "(" expr ")"
+func ParseExpr(src string) (*Expr, error) {
+	p := &exprParser{src: []rune(src), text: src, varIndex: make(map[string]int)}
+	p.next()
+	eval, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.errorf(p.tok.pos, "неожиданный символ %q", p.tok.text)
+	}
+
+	vars := make([]string, len(p.varIndex))
+	for name, i := range p.varIndex {
+		vars[i] = name
+	}
+	return &Expr{src: src, vars: vars, eval: eval}, nil
+}
+
+// ========== ЛЕКСИЧЕСКИЙ АНАЛИЗ ==========
+
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokNum
+	tokIdent
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokKind
+	text string
+	num  float64
+	pos  int // Позиция первого символа (с 1)
+}
+
+type exprParser struct {
+	src      []rune
+	text     string
+	i        int
+	tok      token
+	varIndex map[string]int
+	err      *ParseError
+}
+
+func (p *exprParser) errorf(pos int, format string, args ...any) *ParseError {
+	return &ParseError{Src: p.text, Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// next - чтение следующей лексемы; ошибка лексического анализа
+// сохраняется и возвращается при разборе
+func (p *exprParser) next() {
+	for p.i < len(p.src) && unicode.IsSpace(p.src[p.i]) {
+		p.i++
+	}
+	start := p.i
+	pos := start + 1
+	if p.i >= len(p.src) {
+		p.tok = token{kind: tokEOF, pos: pos, text: "конец выражения"}
+		return
+	}
+
+	c := p.src[p.i]
+	switch {
+	case unicode.IsDigit(c) || c == '.':
+		for p.i < len(p.src) && (unicode.IsDigit(p.src[p.i]) || p.src[p.i] == '.') {
+			p.i++
+		}
+		// Показатель степени: 1e-3, 2.5E+4
+		if p.i < len(p.src) && (p.src[p.i] == 'e' || p.src[p.i] == 'E') {
+			j := p.i + 1
+			if j < len(p.src) && (p.src[j] == '+' || p.src[j] == '-') {
+				j++
+			}
+			if j < len(p.src) && unicode.IsDigit(p.src[j]) {
+				for j < len(p.src) && unicode.IsDigit(p.src[j]) {
+					j++
+				}
+				p.i = j
+			}
+		}
+		text := string(p.src[start:p.i])
+		v, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			p.err = p.errorf(pos, "некорректное число %q", text)
+			p.tok = token{kind: tokEOF, pos: pos}
+			return
+		}
+		p.tok = token{kind: tokNum, text: text, num: v, pos: pos}
+	case unicode.IsLetter(c) || c == '_':
+		for p.i < len(p.src) && (unicode.IsLetter(p.src[p.i]) || unicode.IsDigit(p.src[p.i]) || p.src[p.i] == '_') {
+			p.i++
+		}
+		p.tok = token{kind: tokIdent, text: string(p.src[start:p.i]), pos: pos}
+	case strings.ContainsRune("+-*/^", c):
+		p.i++
+		p.tok = token{kind: tokOp, text: string(c), pos: pos}
+	case c == '(':
+		p.i++
+		p.tok = token{kind: tokLParen, text: "(", pos: pos}
+	case c == ')':
+		p.i++
+		p.tok = token{kind: tokRParen, text: ")", pos: pos}
+	default:
+		p.err = p.errorf(pos, "недопустимый символ %q", string(c))
+		p.tok = token{kind: tokEOF, pos: pos}
+	}
+}
+
+// ========== СИНТАКСИЧЕСКИЙ АНАЛИЗ ==========
+
+type evalFunc = func(env []float64) float64
+
+func (p *exprParser) parseExpr() (evalFunc, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokOp && (p.tok.text == "+" || p.tok.text == "-") {
+		op := p.tok.text
+		p.next()
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		if op == "+" {
+			left = func(env []float64) float64 { return l(env) + right(env) }
+		} else {
+			left = func(env []float64) float64 { return l(env) - right(env) }
+		}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseTerm() (evalFunc, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokOp && (p.tok.text == "*" || p.tok.text == "/") {
+		op := p.tok.text
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		if op == "*" {
+			left = func(env []float64) float64 { return l(env) * right(env) }
+		} else {
+			left = func(env []float64) float64 { return l(env) / right(env) }
+		}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseUnary() (evalFunc, error) {
+	if p.tok.kind == tokOp && (p.tok.text == "+" || p.tok.text == "-") {
+		op := p.tok.text
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if op == "-" {
+			return func(env []float64) float64 { return -operand(env) }, nil
+		}
+		return operand, nil
+	}
+	return p.parsePower()
+}
+
+func (p *exprParser) parsePower() (evalFunc, error) {
+	base, err := p.parseAtom()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokOp && p.tok.text == "^" {
+		p.next()
+		exp, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(env []float64) float64 { return math.Pow(base(env), exp(env)) }, nil
+	}
+	return base, nil
+}
+
+func (p *exprParser) parseAtom() (evalFunc, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	tok := p.tok
+	switch tok.kind {
+	case tokNum:
+		p.next()
+		v := tok.num
+		return func([]float64) float64 { return v }, nil
+
+	case tokIdent:
+		p.next()
+		if f, ok := exprFuncs[tok.text]; ok {
+			if p.tok.kind != tokLParen {
+				return nil, p.errorf(p.tok.pos, "ожидается \"(\" после имени функции %s", tok.text)
+			}
+			arg, err := p.parseParen()
+			if err != nil {
+				return nil, err
+			}
+			return func(env []float64) float64 { return f(arg(env)) }, nil
+		}
+		if p.tok.kind == tokLParen {
+			return nil, p.errorf(tok.pos, "неизвестная функция %q", tok.text)
+		}
+		if c, ok := exprConsts[tok.text]; ok {
+			return func([]float64) float64 { return c }, nil
+		}
+		i, ok := p.varIndex[tok.text]
+		if !ok {
+			i = len(p.varIndex)
+			p.varIndex[tok.text] = i
+		}
+		return func(env []float64) float64 { return env[i] }, nil
+
+	case tokLParen:
+		return p.parseParen()
+
+	case tokEOF:
+		if p.err != nil {
+			return nil, p.err
+		}
+		return nil, p.errorf(tok.pos, "неожиданный конец выражения")
+
+	default:
+		return nil, p.errorf(tok.pos, "неожиданный символ %q", tok.text)
+	}
+}
+
+// parseParen - выражение в скобках; текущая лексема - "("
+func (p *exprParser) parseParen() (evalFunc, error) {
+	open := p.tok.pos
+	p.next()
+	inner, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokRParen {
+		if p.err != nil {
+			return nil, p.err
+		}
+		return nil, p.errorf(p.tok.pos, "ожидается \")\" для скобки в позиции %d", open)
+	}
+	p.next()
+	return inner, nil
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Proposal - вспомогательная плотность для интегрирования по бесконечному
+// промежутку: точки генерируются методом обратной функции InvCDF,
+// испытанием является отношение f(x)/PDF(x)
+type Proposal struct {
+	Name   string
+	PDF    func(x float64) float64
+	InvCDF func(p float64) float64
+}
+
+// ExponentialProposal - экспоненциальная плотность с параметром lambda,
+// сдвинутая в точку a: p(x) = lambda*exp(-lambda*(x-a)), x >= a
+func ExponentialProposal(a, lambda float64) Proposal {
+	return Proposal{
+		Name: "экспоненциальная",
+		PDF: func(x float64) float64 {
+			if x < a {
+				return 0
+			}
+			return lambda * math.Exp(-lambda*(x-a))
+		},
+		InvCDF: func(p float64) float64 {
+			return a - math.Log(1-p)/lambda
+		},
+	}
+}
+
+// NormalProposal - нормальная плотность с параметрами mean, sigma
+func NormalProposal(mean, sigma float64) Proposal {
+	return Proposal{
+		Name: "нормальная",
+		PDF: func(x float64) float64 {
+			z := (x - mean) / sigma
+			return math.Exp(-z*z/2) / (sigma * math.Sqrt(2*math.Pi))
+		},
+		InvCDF: func(p float64) float64 {
+			return mean + sigma*NormalQuantile(p)
+		},
+	}
+}
+
+// ProposalIntegral - интеграл f по области определения плотности prop
+// методом выборки по значимости
+func ProposalIntegral(f func(float64) float64, prop Proposal, expNmb int, level float64) MCResult {
+	var acc Accumulator
+	for i := 0; i < expNmb; i++ {
+		u := rand.Float64()
+		for u == 0 {
+			u = rand.Float64()
+		}
+		x := prop.InvCDF(u)
+		acc.Add(f(x) / prop.PDF(x))
+	}
+	return acc.Result(level)
+}
+
+// semiInfinite - подынтегральная функция на [0, 1) после замены
+// x = a + t/(1-t), dx = dt/(1-t)^2
+func semiInfinite(a float64, f func(float64) float64) func(float64) float64 {
+	return func(t float64) float64 {
+		s := 1 - t
+		return f(a+t/s) / (s * s)
+	}
+}
+
+// infinite - подынтегральная функция на (-1, 1) после замены
+// x = t/(1-t^2), dx = (1+t^2)/(1-t^2)^2 dt
+func infinite(f func(float64) float64) func(float64) float64 {
+	return func(t float64) float64 {
+		s := 1 - t*t
+		return f(t/s) * (1 + t*t) / (s * s)
+	}
+}
+
+// SemiInfiniteIntegral - интеграл f по [a, +∞) методом среднего значения
+// после замены x = a + t/(1-t), переводящей промежуток в [0, 1)
+func SemiInfiniteIntegral(a float64, f func(float64) float64, expNmb int, level float64) MCResult {
+	g := semiInfinite(a, f)
+	var acc Accumulator
+	for i := 0; i < expNmb; i++ {
+		acc.Add(g(rand.Float64()))
+	}
+	return acc.Result(level)
+}
+
+// InfiniteIntegral - интеграл f по (-∞, +∞) после замены x = t/(1-t^2),
+// переводящей промежуток в (-1, 1). Точки t и -t берутся парами, поэтому
+// концы промежутка t = ±1 не генерируются.
+func InfiniteIntegral(f func(float64) float64, expNmb int, level float64) MCResult {
+	g := infinite(f)
+	var acc Accumulator
+	for i := 0; i < expNmb; i++ {
+		t := rand.Float64()
+		acc.Add(g(t) + g(-t))
+	}
+	return acc.Result(level)
+}
+
+// checkImproperBounds - проверка пределов интегрирования: нижний предел
+// не может быть +∞, верхний - -∞
+func checkImproperBounds(a, b float64) error {
+	switch {
+	case math.IsNaN(a) || math.IsNaN(b):
+		return fmt.Errorf("пределы интегрирования не заданы: [%g, %g]", a, b)
+	case math.IsInf(a, 1) || math.IsInf(b, -1):
+		return fmt.Errorf("некорректный промежуток [%g, %g]: нижний предел должен быть меньше верхнего", a, b)
+	}
+	return nil
+}
+
+// ImproperIntegral - интеграл f по [a, b], где a и b могут быть
+// бесконечными (math.Inf); конечный промежуток считается методом
+// среднего значения
+func ImproperIntegral(a, b float64, f func(float64) float64, expNmb int, level float64) (MCResult, error) {
+	if err := checkImproperBounds(a, b); err != nil {
+		return MCResult{}, err
+	}
+	switch {
+	case math.IsInf(a, -1) && math.IsInf(b, 1):
+		return InfiniteIntegral(f, expNmb, level), nil
+	case math.IsInf(b, 1):
+		return SemiInfiniteIntegral(a, f, expNmb, level), nil
+	case math.IsInf(a, -1):
+		// Отражение x -> -x: интеграл по (-∞, b] равен интегралу f(-x) по [-b, +∞)
+		return SemiInfiniteIntegral(-b, func(x float64) float64 { return f(-x) }, expNmb, level), nil
+	default:
+		return SampleMeanIntegral(a, b, f, expNmb, level), nil
+	}
+}
+
+// ReferenceImproper - эталонное значение интеграла по промежутку
+// с бесконечными концами: квадратура Гаусса - Кронрода после тех же замен
+// переменной (узлы правила G7-K15 не совпадают с концами промежутка)
+func ReferenceImproper(a, b float64, f func(float64) float64) (QuadResult, error) {
+	if err := checkImproperBounds(a, b); err != nil {
+		return QuadResult{}, err
+	}
+	switch {
+	case math.IsInf(a, -1) && math.IsInf(b, 1):
+		return ReferenceIntegral(-1, 1, infinite(f)), nil
+	case math.IsInf(b, 1):
+		return ReferenceIntegral(0, 1, semiInfinite(a, f)), nil
+	case math.IsInf(a, -1):
+		return ReferenceIntegral(0, 1, semiInfinite(-b, func(x float64) float64 { return f(-x) })), nil
+	default:
+		return ReferenceIntegral(a, b, f), nil
+	}
+}
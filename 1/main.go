@@ -1,9 +1,12 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"math"
 	"math/rand"
+	"runtime"
+	"time"
 )
 
 // Задание 4: функция CALC_INTEGRAL
@@ -53,122 +56,432 @@ func CALC_PI(x0, y0, r0 float64, expNmb int) float64 {
 
 func main() {
 
-	// Задание 2: расчет значения числа Пи для заданной окружности и различного
-	// количества экспериментов
-
-	var SERIA_1, SERIA_2, SERIA_3, SERIA_4, SERIA_5 [5]float64
-	for i := 4; i <= 8; i++ {
-		SERIA_1[i-4] = CALC_PI(1.0, 2.0, 5.0, int(math.Pow(10, float64(i))))
-	}
-	for i := 4; i <= 8; i++ {
-		SERIA_2[i-4] = CALC_PI(1.0, 2.0, 5.0, int(math.Pow(10, float64(i))))
-	}
-	for i := 4; i <= 8; i++ {
-		SERIA_3[i-4] = CALC_PI(1.0, 2.0, 5.0, int(math.Pow(10, float64(i))))
-	}
-	for i := 4; i <= 8; i++ {
-		SERIA_4[i-4] = CALC_PI(1.0, 2.0, 5.0, int(math.Pow(10, float64(i))))
-	}
-	for i := 4; i <= 8; i++ {
-		SERIA_5[i-4] = CALC_PI(1.0, 2.0, 5.0, int(math.Pow(10, float64(i))))
-	}
-	fmt.Println("Результаты вычисления значения числа Пи для первой серии экспериментов (SERIA_1):", SERIA_1)
-	fmt.Println("Результаты вычисления значения числа Пи для второй серии экспериментов (SERIA_2):", SERIA_2)
-	fmt.Println("Результаты вычисления значения числа Пи для третьей серии экспериментов (SERIA_3):", SERIA_3)
-	fmt.Println("Результаты вычисления значения числа Пи для четвертой серии экспериментов (SERIA_4):", SERIA_4)
-	fmt.Println("Результаты вычисления значения числа Пи для пятой серии экспериментов (SERIA_5):", SERIA_5, "\n")
-
-	// Задание 3: расчет погрешности вычислений значений числа Пи
-
-	var Eps1, Eps2, Eps3, Eps4, Eps5 [5]float64
-	for i := 0; i < 5; i++ {
-		Eps1[i] = math.Abs((SERIA_1[i] - math.Pi) / math.Pi)
-		Eps2[i] = math.Abs((SERIA_2[i] - math.Pi) / math.Pi)
-		Eps3[i] = math.Abs((SERIA_3[i] - math.Pi) / math.Pi)
-		Eps4[i] = math.Abs((SERIA_4[i] - math.Pi) / math.Pi)
-		Eps5[i] = math.Abs((SERIA_5[i] - math.Pi) / math.Pi)
-	}
-	fmt.Println("Погрешности вычислений значений числа Пи для первой серии экспериментов (SERIA_1):", Eps1)
-	fmt.Println("Погрешности вычислений значений числа Пи для второй серии экспериментов (SERIA_2):", Eps2)
-	fmt.Println("Погрешности вычислений значений числа Пи для третьей серии экспериментов (SERIA_3):", Eps3)
-	fmt.Println("Погрешности вычислений значений числа Пи для четвертой серии экспериментов (SERIA_4):", Eps4)
-	fmt.Println("Погрешности вычислений значений числа Пи для пятой серии экспериментов (SERIA_5):", Eps5, "\n")
-
-	var S_e4, S_e5, S_e6, S_e7, S_e8 float64
-	S_e4 = (SERIA_1[0] + SERIA_2[0] + SERIA_3[0] + SERIA_4[0] + SERIA_5[0]) / 5
-	S_e5 = (SERIA_1[1] + SERIA_2[1] + SERIA_3[1] + SERIA_4[1] + SERIA_5[1]) / 5
-	S_e6 = (SERIA_1[2] + SERIA_2[2] + SERIA_3[2] + SERIA_4[2] + SERIA_5[2]) / 5
-	S_e7 = (SERIA_1[3] + SERIA_2[3] + SERIA_3[3] + SERIA_4[3] + SERIA_5[3]) / 5
-	S_e8 = (SERIA_1[4] + SERIA_2[4] + SERIA_3[4] + SERIA_4[4] + SERIA_5[4]) / 5
-
-	var Eps_S_e4, Eps_S_e5, Eps_S_e6, Eps_S_e7, Eps_S_e8 float64
-	Eps_S_e4 = math.Abs((S_e4 - math.Pi) / math.Pi)
-	Eps_S_e5 = math.Abs((S_e5 - math.Pi) / math.Pi)
-	Eps_S_e6 = math.Abs((S_e6 - math.Pi) / math.Pi)
-	Eps_S_e7 = math.Abs((S_e7 - math.Pi) / math.Pi)
-	Eps_S_e8 = math.Abs((S_e8 - math.Pi) / math.Pi)
-	fmt.Println("Погрешность вычислений для усредненного значения вычисленного числа Пи при ExpNmb=10^4:", Eps_S_e4)
-	fmt.Println("Погрешность вычислений для усредненного значения вычисленного числа Пи при ExpNmb=10^5:", Eps_S_e5)
-	fmt.Println("Погрешность вычислений для усредненного значения вычисленного числа Пи при ExpNmb=10^6:", Eps_S_e6)
-	fmt.Println("Погрешность вычислений для усредненного значения вычисленного числа Пи при ExpNmb=10^7:", Eps_S_e7)
-	fmt.Println("Погрешность вычислений для усредненного значения вычисленного числа Пи при ExpNmb=10^8:", Eps_S_e8, "\n")
+	// Интегрирование произвольной функции, заданной строкой:
+	// go run . -f "sin(x)^2" -a 0 -b 3.14159
+	FuncFlag := flag.String("f", "", "подынтегральная функция от x, например \"x^3+1\"")
+	AFlag := flag.Float64("a", 0, "нижний предел интегрирования")
+	BFlag := flag.Float64("b", 1, "верхний предел интегрирования")
+	NFlag := flag.Int("n", 1000000, "количество экспериментов")
+	flag.Parse()
+	if *NFlag < 1 {
+		fmt.Println("Количество экспериментов должно быть положительным:", *NFlag)
+		return
+	}
+	if *FuncFlag != "" {
+		f, err := CompileFunc(*FuncFlag)
+		if err != nil {
+			if perr, ok := err.(*ParseError); ok {
+				fmt.Println(perr.Caret())
+			}
+			fmt.Println("Ошибка разбора функции:", err)
+			return
+		}
+		if math.IsInf(*AFlag, 0) || math.IsInf(*BFlag, 0) {
+			// Бесконечный промежуток: -a -inf или -b inf
+			Improper, err := ImproperIntegral(*AFlag, *BFlag, f, *NFlag, DefaultLevel)
+			if err != nil {
+				fmt.Println("Ошибка пределов интегрирования:", err)
+				return
+			}
+			Reference, err := ReferenceImproper(*AFlag, *BFlag, f)
+			if err != nil {
+				fmt.Println("Ошибка пределов интегрирования:", err)
+				return
+			}
+			fmt.Printf("Интеграл функции y = %s на [%g;%g]:\n", *FuncFlag, *AFlag, *BFlag)
+			fmt.Printf("  Метод среднего значения после замены переменной: %.8f ± %.8f\n", Improper.Estimate, Improper.HalfWidth())
+			fmt.Printf("  Квадратура Гаусса - Кронрода: %.12f (оценка погрешности %.1e)\n", Reference.Value, Reference.AbsErr)
+			fmt.Printf("  Погрешность: %.3e\n", math.Abs(Improper.Estimate-Reference.Value))
+			return
+		}
+		HitOrMiss := CALC_INTEGRAL_AUTO(*AFlag, *BFlag, f, *NFlag)
+		SampleMean := SampleMeanIntegral(*AFlag, *BFlag, f, *NFlag, DefaultLevel)
+		fmt.Printf("Интеграл функции y = %s на [%g;%g]:\n", *FuncFlag, *AFlag, *BFlag)
+		fmt.Printf("  Метод \"попал/не попал\": %.8f ± %.8f\n", HitOrMiss.Estimate, HitOrMiss.HalfWidth())
+		fmt.Printf("  Метод среднего значения: %.8f ± %.8f\n", SampleMean.Estimate, SampleMean.HalfWidth())
+
+		// Эталонное значение для расчета погрешностей находится квадратурой
+		Reference := ReferenceIntegral(*AFlag, *BFlag, f)
+		fmt.Printf("  Квадратура Гаусса - Кронрода: %.12f (оценка погрешности %.1e, вычислений функции: %d)\n",
+			Reference.Value, Reference.AbsErr, Reference.Evaluations)
+		fmt.Printf("  Погрешность \"попал/не попал\": %.3e, среднего значения: %.3e\n",
+			math.Abs(HitOrMiss.Estimate-Reference.Value), math.Abs(SampleMean.Estimate-Reference.Value))
+		if Reference.Value != 0 {
+			fmt.Println()
+			// Сетка от 10^3 до наибольшей степени десяти, не превышающей -n;
+			// для оценки порядка сходимости нужно хотя бы два значения N
+			Grid, err := PowerGrid(3, int(math.Log10(float64(*NFlag))))
+			if err != nil || len(Grid) < 2 {
+				fmt.Println("Исследование сходимости пропущено: нужно -n не меньше 10000")
+				return
+			}
+			FuncStudy := RunConvergenceStudy(fmt.Sprintf("Интеграл функции y = %s на [%g;%g]", *FuncFlag, *AFlag, *BFlag),
+				func(expNmb int) float64 {
+					return CALC_INTEGRAL_AUTO(*AFlag, *BFlag, f, expNmb).Estimate
+				},
+				Reference.Value, Grid, 3)
+			FuncStudy.Print()
+		}
+		return
+	}
+
+	// Задания 2, 3: расчет значения числа Пи для заданной окружности и различного
+	// количества экспериментов (пять серий), расчет погрешности вычислений
+
+	PiGrid, err := PowerGrid(4, 8)
+	if err != nil {
+		fmt.Println("Ошибка сетки экспериментов:", err)
+		return
+	}
+	PiStudy := RunConvergenceStudy("Число Пи",
+		func(expNmb int) float64 {
+			return CALC_PI(1.0, 2.0, 5.0, expNmb)
+		},
+		math.Pi, PiGrid, 5)
+	PiStudy.Print()
+	PiStudy.Save("pi_convergence")
+	fmt.Println()
 
 	// Задание 4
 
-	var INTEGRAL_SERIA_1, INTEGRAL_SERIA_2, INTEGRAL_SERIA_3 [4]float64
-	for i := 4; i <= 7; i++ {
-		INTEGRAL_SERIA_1[i-4] = CALC_INTEGRAL(0, 2.0,
-			func(x float64) float64 {
-				return x*x*x + 1
-			},
-			int(math.Pow(10, float64(i))))
+	// Значение CorrectIntergralValue равно значению интеграла функции y=x^3+1 на промежутке [0;2]
+	// К этому значению можно прийти классическими методами расчета интеграла на бумаге
+	const CorrectIntergralValue = 6
+
+	cube, err := CompileFunc("x^3+1")
+	if err != nil {
+		fmt.Println("Ошибка разбора подынтегральной функции:", err)
+		return
 	}
-	for i := 4; i <= 7; i++ {
-		INTEGRAL_SERIA_2[i-4] = CALC_INTEGRAL(0, 2.0,
-			func(x float64) float64 {
-				return x*x*x + 1
-			},
-			int(math.Pow(10, float64(i))))
+	xSinX, err := CompileFunc("x*sin(x)")
+	if err != nil {
+		fmt.Println("Ошибка разбора подынтегральной функции:", err)
+		return
 	}
+	IntegralGrid, err := PowerGrid(4, 7)
+	if err != nil {
+		fmt.Println("Ошибка сетки экспериментов:", err)
+		return
+	}
+	IntegralStudy := RunConvergenceStudy("Интеграл функции y = x^3+1 на [0;2]",
+		func(expNmb int) float64 {
+			return CALC_INTEGRAL(0, 2.0, cube, expNmb)
+		},
+		CorrectIntergralValue, IntegralGrid, 3)
+	IntegralStudy.Print()
+	IntegralStudy.Save("integral_convergence")
+	fmt.Println()
+
+	// Дополнительно: детерминированные квадратуры как эталон для расчета
+	// погрешностей, когда точное значение интеграла заранее неизвестно
+
+	fmt.Printf("%-34s %22s %14s %14s %12s\n", "Квадратура", "Значение", "Оценка погр.", "Факт. погр.", "Вычислений")
+	for _, Quad := range []struct {
+		Name  string
+		F     func(float64) float64
+		A, B  float64
+		Exact float64
+		Run   func(a, b float64, f func(float64) float64) QuadResult
+	}{
+		{"Симпсон, 100 отрезков", cube, 0, 2, CorrectIntergralValue, func(a, b float64, f func(float64) float64) QuadResult {
+			return Simpson(a, b, f, 100)
+		}},
+		{"Гаусс - Лежандр, 5 узлов x 2", cube, 0, 2, CorrectIntergralValue, func(a, b float64, f func(float64) float64) QuadResult {
+			return GaussLegendre(a, b, f, 5, 2)
+		}},
+		{"Симпсон, 100 отрезков (x*sin(x))", xSinX, 0, 2 * math.Pi, -2 * math.Pi, func(a, b float64, f func(float64) float64) QuadResult {
+			return Simpson(a, b, f, 100)
+		}},
+		{"Гаусс - Лежандр, 5 узлов x 8", xSinX, 0, 2 * math.Pi, -2 * math.Pi, func(a, b float64, f func(float64) float64) QuadResult {
+			return GaussLegendre(a, b, f, 5, 8)
+		}},
+		{"Гаусс - Кронрод (x*sin(x))", xSinX, 0, 2 * math.Pi, -2 * math.Pi, ReferenceIntegral},
+	} {
+		Res := Quad.Run(Quad.A, Quad.B, Quad.F)
+		fmt.Printf("%-34s %22.15f %14.3e %14.3e %12d\n",
+			Quad.Name, Res.Value, Res.AbsErr, math.Abs(Res.Value-Quad.Exact), Res.Evaluations)
+	}
+	fmt.Println()
+
+	// Дополнительно: интегрирование немонотонной знакопеременной функции
+	// с автоматическим поиском охватывающего прямоугольника
+
+	// Значение интеграла функции y = x*sin(x) на промежутке [0;2π] равно -2π
+	const CorrectSignedIntegralValue = -2 * math.Pi
+
+	Env := FindEnvelope(0, 2*math.Pi, xSinX)
+	fmt.Println("Найденный диапазон значений функции y = x*sin(x) на [0;2π]:", Env.YMin, Env.YMax)
 	for i := 4; i <= 7; i++ {
-		INTEGRAL_SERIA_3[i-4] = CALC_INTEGRAL(0, 2.0,
-			func(x float64) float64 {
-				return x*x*x + 1
-			},
-			int(math.Pow(10, float64(i))))
+		Res := HitOrMissIntegral(0, 2*math.Pi, xSinX, Env, int(math.Pow(10, float64(i))), DefaultLevel)
+		fmt.Printf("ExpNmb=10^%d: интеграл = %.6f, положительных попаданий = %d, отрицательных = %d, погрешность = %.6f\n",
+			i, Res.Estimate, Res.PositiveHits, Res.NegativeHits,
+			math.Abs((Res.Estimate-CorrectSignedIntegralValue)/CorrectSignedIntegralValue))
 	}
-	fmt.Println("Результаты нахождения значения определенного интеграла функции y = x^3+1 для первой серии экспериментов:", INTEGRAL_SERIA_1)
-	fmt.Println("Результаты нахождения значения определенного интеграла функции y = x^3+1 для второй серии экспериментов:", INTEGRAL_SERIA_2)
-	fmt.Println("Результаты нахождения значения определенного интеграла функции y = x^3+1 для третьей серии экспериментов:", INTEGRAL_SERIA_3, "\n")
+	fmt.Println()
 
-	// Значение CorrectIntergralValue равно значению интеграла функции y=x^3+1 на промежутке [0;2]
-	// К этому значению можно прийти классическими методами расчета интеграла на бумаге
-	const CorrectIntergralValue = 6
+	// Дополнительно: несобственные интегралы - математические ожидания
+	// для распределений из лабораторных работ 3, 5 и 6. Сравниваются замена
+	// переменной, переводящая бесконечный промежуток в конечный, и выборка
+	// из вспомогательной плотности.
+
+	const ImproperExpNmb = 1000000
+	fmt.Printf("%-38s %-34s %12s %12s %12s %12s\n",
+		"Величина", "Метод", "Оценка", "Ст. ошибка", "Эталон", "Точное")
+	for _, Task := range []struct {
+		Name  string
+		Src   string
+		A, B  float64
+		Exact float64
+		Prop  Proposal
+	}{
+		// Экспоненциальное распределение, λ=2: E[X^2] = 2/λ^2
+		{"E[X^2], экспоненциальное λ=2", "x^2*2*exp(-2*x)", 0, math.Inf(1), 0.5, ExponentialProposal(0, 1)},
+		// Нормальное распределение, M=10, σ=2: E[X^2] = M^2 + σ^2
+		{"E[X^2], нормальное M=10, σ=2", "x^2*exp(-(x-10)^2/8)/(2*sqrt(2*pi))", math.Inf(-1), math.Inf(1), 104, NormalProposal(10, 3)},
+		// Распределение Вейбулла, λ=2, k=2: E[X] = λ Γ(1+1/k) = √π
+		{"E[X], Вейбулл λ=2, k=2", "x*(x/2)*exp(-(x/2)^2)", 0, math.Inf(1), math.Sqrt(math.Pi), ExponentialProposal(0, 0.5)},
+	} {
+		f, err := CompileFunc(Task.Src)
+		if err != nil {
+			fmt.Println("Ошибка разбора подынтегральной функции:", err)
+			return
+		}
+		Reference, err := ReferenceImproper(Task.A, Task.B, f)
+		if err != nil {
+			fmt.Println("Ошибка пределов интегрирования:", err)
+			return
+		}
+		Improper, err := ImproperIntegral(Task.A, Task.B, f, ImproperExpNmb, DefaultLevel)
+		if err != nil {
+			fmt.Println("Ошибка пределов интегрирования:", err)
+			return
+		}
+		for _, Method := range []struct {
+			Name string
+			Res  MCResult
+		}{
+			{"замена переменной", Improper},
+			{"плотность: " + Task.Prop.Name, ProposalIntegral(f, Task.Prop, ImproperExpNmb, DefaultLevel)},
+		} {
+			fmt.Printf("%-38s %-34s %12.6f %12.6f %12.6f %12.6f\n",
+				Task.Name, Method.Name, Method.Res.Estimate, Method.Res.StdErr, Reference.Value, Task.Exact)
+		}
+	}
+	fmt.Println()
+
+	// Дополнительно: сравнение эффективности метода "попал/не попал" и
+	// метода среднего значения для функции y = x^3+1 на [0;2].
+	// Эффективность - величина, обратная произведению дисперсии одного
+	// испытания на время одного испытания.
+
+	const CompareExpNmb = 1000000
+	fmt.Printf("%-22s %12s %14s %12s %26s %14s\n",
+		"Метод", "Оценка", "Дисперсия", "Ст. ошибка", "95% доверительный интервал", "Эффективность")
+	for _, Method := range []struct {
+		Name string
+		Run  func() MCResult
+	}{
+		{"Попал/не попал", func() MCResult {
+			return CALC_INTEGRAL_STATS(0, 2.0, cube, CompareExpNmb, DefaultLevel)
+		}},
+		{"Среднее значение", func() MCResult {
+			return SampleMeanIntegral(0, 2.0, cube, CompareExpNmb, DefaultLevel)
+		}},
+	} {
+		Start := time.Now()
+		Res := Method.Run()
+		PerSample := time.Since(Start).Seconds() / CompareExpNmb
+		fmt.Printf("%-22s %12.6f %14.6f %12.6f   [%10.6f; %10.6f] %14.4g\n",
+			Method.Name, Res.Estimate, Res.Variance, Res.StdErr, Res.CILow, Res.CIHigh,
+			1/(Res.Variance*PerSample))
+	}
+
+	PiRes := CALC_PI_STATS(1.0, 2.0, 5.0, CompareExpNmb, DefaultLevel)
+	fmt.Printf("\nЧисло Пи при ExpNmb=10^6: %.6f ± %.6f (95%% доверительный интервал [%.6f; %.6f])\n",
+		PiRes.Estimate, PiRes.HalfWidth(), PiRes.CILow, PiRes.CIHigh)
+	fmt.Println()
+
+	// Дополнительно: другие геометрико-вероятностные оценки числа Пи.
+	// Дисперсия одного испытания для оценок по доле попаданий находится
+	// дельта-методом, поэтому все оценки сравниваются в одной таблице.
+
+	fmt.Printf("%-34s %12s %12s %14s %14s\n",
+		"Оценка числа Пи", "Оценка", "Ст. ошибка", "Дисп. испыт.", "Эффективность")
+	for _, Method := range []struct {
+		Name string
+		Run  func() MCResult
+	}{
+		{"Круг в квадрате (CALC_PI)", func() MCResult {
+			return CALC_PI_STATS(1.0, 2.0, 5.0, CompareExpNmb, DefaultLevel)
+		}},
+		{"Игла Бюффона, l=t=1", func() MCResult {
+			return BuffonNeedle(1, 1, CompareExpNmb, DefaultLevel)
+		}},
+		{"Игла Бюффона, l=0.5, t=1", func() MCResult {
+			return BuffonNeedle(0.5, 1, CompareExpNmb, DefaultLevel)
+		}},
+		{"Бюффон - Лаплас, l=a=b=1", func() MCResult {
+			return BuffonLaplace(1, 1, 1, CompareExpNmb, DefaultLevel)
+		}},
+		{"Взаимно простые числа", func() MCResult {
+			return CoprimePi(CompareExpNmb, DefaultLevel)
+		}},
+	} {
+		Start := time.Now()
+		Res := Method.Run()
+		PerSample := time.Since(Start).Seconds() / CompareExpNmb
+		fmt.Printf("%-34s %12.6f %12.6f %14.4f %14.4g\n",
+			Method.Name, Res.Estimate, Res.StdErr, Res.Variance, 1/(Res.Variance*PerSample))
+	}
+	fmt.Println()
+
+	// Дополнительно: методы понижения дисперсии для функции y = x^3+1 на [0;2]
+
+	const VRExpNmb = 100000
+
+	// Контрольная функция y = x^2, её интеграл на [0;2] равен 8/3
+	square := func(x float64) float64 {
+		return x * x
+	}
 
-	var Integral_Eps1, Integral_Eps2, Integral_Eps3 [4]float64
-	for i := 0; i < 4; i++ {
-		Integral_Eps1[i] = math.Abs((INTEGRAL_SERIA_1[i] - CorrectIntergralValue) / CorrectIntergralValue)
-		Integral_Eps2[i] = math.Abs((INTEGRAL_SERIA_2[i] - CorrectIntergralValue) / CorrectIntergralValue)
-		Integral_Eps3[i] = math.Abs((INTEGRAL_SERIA_3[i] - CorrectIntergralValue) / CorrectIntergralValue)
-	}
-	fmt.Println("Погрешности нахождений значения определенного интеграла функции y = x^3+1 для первой серии экспериментов:", Integral_Eps1)
-	fmt.Println("Погрешности нахождений значения определенного интеграла функции y = x^3+1 для второй серии экспериментов:", Integral_Eps2)
-	fmt.Println("Погрешности нахождений значения определенного интеграла функции y = x^3+1 для третьей серии экспериментов:", Integral_Eps3, "\n")
-
-	var Integral_S_e4, Integral_S_e5, Integral_S_e6, Integral_S_e7 float64
-	Integral_S_e4 = (INTEGRAL_SERIA_1[0] + INTEGRAL_SERIA_2[0] + INTEGRAL_SERIA_3[0]) / 3
-	Integral_S_e5 = (INTEGRAL_SERIA_1[1] + INTEGRAL_SERIA_2[1] + INTEGRAL_SERIA_3[1]) / 3
-	Integral_S_e6 = (INTEGRAL_SERIA_1[2] + INTEGRAL_SERIA_2[2] + INTEGRAL_SERIA_3[2]) / 3
-	Integral_S_e7 = (INTEGRAL_SERIA_1[3] + INTEGRAL_SERIA_2[3] + INTEGRAL_SERIA_3[3]) / 3
-
-	var Integral_Eps_S_e4, Integral_Eps_S_e5, Integral_Eps_S_e6, Integral_Eps_S_e7 float64
-	Integral_Eps_S_e4 = math.Abs((Integral_S_e4 - CorrectIntergralValue) / CorrectIntergralValue)
-	Integral_Eps_S_e5 = math.Abs((Integral_S_e5 - CorrectIntergralValue) / CorrectIntergralValue)
-	Integral_Eps_S_e6 = math.Abs((Integral_S_e6 - CorrectIntergralValue) / CorrectIntergralValue)
-	Integral_Eps_S_e7 = math.Abs((Integral_S_e7 - CorrectIntergralValue) / CorrectIntergralValue)
-
-	fmt.Println("Погрешность вычислений для усредненного значения найденного значения определенного интеграла функции y = x^3+1 при ExpNmb=10^4:", Integral_Eps_S_e4)
-	fmt.Println("Погрешность вычислений для усредненного значения найденного значения определенного интеграла функции y = x^3+1 при ExpNmb=10^5:", Integral_Eps_S_e5)
-	fmt.Println("Погрешность вычислений для усредненного значения найденного значения определенного интеграла функции y = x^3+1 при ExpNmb=10^6:", Integral_Eps_S_e6)
-	fmt.Println("Погрешность вычислений для усредненного значения найденного значения определенного интеграла функции y = x^3+1 при ExpNmb=10^7:", Integral_Eps_S_e7)
+	// Плотность для выборки по значимости: p(x) ~ exp(βx) на [0;2],
+	// β выбрано так, чтобы p(2)/p(0) = f(2)/f(0) = 9
+	Beta := math.Log(9) / 2
+	Norm := math.Exp(2*Beta) - 1
+	expPDF := func(x float64) float64 {
+		return Beta * math.Exp(Beta*x) / Norm
+	}
+	expInvCDF := func(u float64) float64 {
+		return math.Log(1+u*Norm) / Beta
+	}
+
+	Plain := SampleMeanIntegral(0, 2.0, cube, VRExpNmb, DefaultLevel)
+	Stratified, err := StratifiedIntegral(0, 2.0, cube, 100, VRExpNmb, DefaultLevel)
+	if err != nil {
+		fmt.Println("Ошибка расслоенной выборки:", err)
+		return
+	}
+	fmt.Printf("%-28s %12s %12s %14s\n", "Метод (ExpNmb=10^5)", "Оценка", "Ст. ошибка", "Снижение D")
+	fmt.Printf("%-28s %12.6f %12.6f %14.2f\n", "Простой (среднее значение)", Plain.Estimate, Plain.StdErr, 1.0)
+	for _, Method := range []struct {
+		Name string
+		Res  VRResult
+	}{
+		{"Антитетические переменные", AntitheticIntegral(0, 2.0, cube, VRExpNmb, DefaultLevel)},
+		{"Контрольная переменная x^2", ControlVariateIntegral(0, 2.0, cube, square, 8.0/3.0, VRExpNmb, DefaultLevel)},
+		{"Расслоение (100 слоев)", Stratified},
+		{"Выборка по значимости", ImportanceIntegral(0, 2.0, cube, expPDF, expInvCDF, VRExpNmb, DefaultLevel)},
+	} {
+		fmt.Printf("%-28s %12.6f %12.6f %14.2f\n", Method.Name, Method.Res.Estimate, Method.Res.StdErr, Method.Res.Reduction)
+	}
+	fmt.Println()
+
+	// Дополнительно: многомерное интегрирование. Самопроверка по объемам
+	// единичных n-мерных шаров
+
+	const MultiExpNmb = 1000000
+	fmt.Printf("%-12s %12s %12s %12s %10s\n", "Размерность", "Точный", "Оценка", "Ст. ошибка", "В 95% ДИ")
+	for _, Check := range CheckBallVolumes(10, MultiExpNmb, DefaultLevel) {
+		fmt.Printf("%-12d %12.6f %12.6f %12.6f %10v\n",
+			Check.Dim, Check.Exact, Check.Result.Estimate, Check.Result.StdErr, Check.InCI)
+	}
+
+	// Интеграл функции y = x1 по симплексу x1+x2+x3 <= 1, x >= 0 равен 1/24
+	Simplex := InPolytope([][]float64{{1, 1, 1}}, []float64{1})
+	SimplexRes := MultiIntegral(CubeBox(3, 0, 1),
+		func(x []float64) float64 {
+			return x[0]
+		},
+		Simplex, MultiExpNmb, DefaultLevel)
+	fmt.Printf("\nИнтеграл x1 по трехмерному симплексу: %.6f ± %.6f (точное значение %.6f)\n",
+		SimplexRes.Estimate, SimplexRes.HalfWidth(), 1.0/24)
+
+	// Вероятность того, что размах пяти независимых U[0;1] величин меньше 1/2:
+	// P(R < r) = n r^(n-1) - (n-1) r^n = 0.1875
+	RangeRes := MultiIntegral(CubeBox(5, 0, 1), nil,
+		func(x []float64) bool {
+			lo, hi := x[0], x[0]
+			for _, v := range x[1:] {
+				lo = math.Min(lo, v)
+				hi = math.Max(hi, v)
+			}
+			return hi-lo < 0.5
+		},
+		MultiExpNmb, DefaultLevel)
+	fmt.Printf("P(размах пяти U[0;1] < 0.5): %.6f ± %.6f (точное значение %.6f)\n",
+		RangeRes.Estimate, RangeRes.HalfWidth(), 0.1875)
+	fmt.Println()
+
+	// Дополнительно: квазислучайные последовательности. Таблица сходимости
+	// показывает убывание погрешности ~1/N для последовательностей Халтона
+	// и Соболя против ~1/sqrt(N) для псевдослучайных точек
+
+	Rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	fmt.Println("Погрешность вычисления интеграла функции y = x^3+1 методом среднего значения:")
+	fmt.Printf("%-8s %14s %14s %14s\n", "N", "Псевдослуч.", "Халтон", "Соболь")
+	for k := 10; k <= 20; k += 2 {
+		N := 1 << k
+		MC := SampleMeanIntegralQMC(0, 2.0, cube, NewRandomPoints(1, Rng), N)
+		HaltonRes := SampleMeanIntegralQMC(0, 2.0, cube, NewHalton(1), N)
+		SobolRes := SampleMeanIntegralQMC(0, 2.0, cube, NewSobol(1), N)
+		fmt.Printf("2^%-6d %14.3e %14.3e %14.3e\n", k,
+			math.Abs(MC-CorrectIntergralValue), math.Abs(HaltonRes-CorrectIntergralValue), math.Abs(SobolRes-CorrectIntergralValue))
+	}
+
+	fmt.Println("Погрешность вычисления числа Пи:")
+	fmt.Printf("%-8s %14s %14s %14s\n", "N", "Псевдослуч.", "Халтон", "Соболь")
+	for k := 10; k <= 20; k += 2 {
+		N := 1 << k
+		MC := CALC_PI_QMC(1.0, 2.0, 5.0, NewRandomPoints(2, Rng), N)
+		HaltonRes := CALC_PI_QMC(1.0, 2.0, 5.0, NewHalton(2), N)
+		SobolRes := CALC_PI_QMC(1.0, 2.0, 5.0, NewSobol(2), N)
+		fmt.Printf("2^%-6d %14.3e %14.3e %14.3e\n", k,
+			math.Abs(MC-math.Pi), math.Abs(HaltonRes-math.Pi), math.Abs(SobolRes-math.Pi))
+	}
+
+	// Оценка погрешности по 16 независимым рандомизациям при N=2^16
+	const RQMCReplications = 16
+	const RQMCExpNmb = 1 << 16
+	piEstimator := func(src PointSource) float64 {
+		return CALC_PI_QMC(1.0, 2.0, 5.0, src, RQMCExpNmb)
+	}
+	for _, Source := range []struct {
+		Name string
+		New  func(rng *rand.Rand) PointSource
+	}{
+		{"Псевдослучайные точки", func(rng *rand.Rand) PointSource { return NewRandomPoints(2, rng) }},
+		{"Перемешанный Халтон", func(rng *rand.Rand) PointSource { return NewScrambledHalton(2, rng) }},
+		{"Соболь со сдвигом", func(rng *rand.Rand) PointSource { return NewShiftedSobol(2, rng) }},
+	} {
+		Res := RandomizedQMC(RQMCReplications, Source.New, piEstimator, Rng, DefaultLevel)
+		fmt.Printf("%-24s Пи = %.6f ± %.6f (ст. ошибка %.2e)\n", Source.Name, Res.Estimate, Res.HalfWidth(), Res.StdErr)
+	}
+	fmt.Println()
+
+	// Дополнительно: параллельный расчет числа Пи при ExpNmb=10^8.
+	// Результат при одном зерне не зависит от количества горутин.
+
+	const ParallelExpNmb = 100000000
+	const ParallelSeed = 2024
+	Start := time.Now()
+	SeqPi := CALC_PI(1.0, 2.0, 5.0, ParallelExpNmb)
+	fmt.Printf("CALC_PI (одна горутина): Пи = %.8f, время %v\n", SeqPi, time.Since(Start))
+	for _, Workers := range []int{1, 2, runtime.NumCPU()} {
+		Start = time.Now()
+		Res := CALC_PI_PARALLEL(1.0, 2.0, 5.0, ParallelExpNmb, Workers, ParallelSeed, DefaultLevel)
+		fmt.Printf("CALC_PI_PARALLEL (%2d горутин): Пи = %.8f ± %.8f, время %v\n",
+			Workers, Res.Estimate, Res.HalfWidth(), time.Since(Start))
+	}
+	fmt.Println()
+
+	// Дополнительно: адаптивный метод - испытания проводятся до достижения
+	// заданной относительной погрешности или исчерпания бюджета
+
+	for _, RelErr := range []float64{1e-2, 1e-3, 1e-4} {
+		Rule := StopRule{RelErr: RelErr, Budget: 100000000}
+		PiRes := CALC_PI_ADAPTIVE(1.0, 2.0, 5.0, Rule, ParallelSeed)
+		IntRes := CALC_INTEGRAL_ADAPTIVE(0, 2.0, cube, Rule, ParallelSeed)
+		fmt.Printf("Точность %.0e: Пи = %.6f (испытаний %d, достигнута: %v); интеграл y = x^3+1 = %.6f (испытаний %d, достигнута: %v)\n",
+			RelErr, PiRes.Estimate, PiRes.ExpNmb, PiRes.Reached, IntRes.Estimate, IntRes.ExpNmb, IntRes.Reached)
+	}
 }
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Box - n-мерный прямоугольный параллелепипед [Min[0], Max[0]] x ... x [Min[n-1], Max[n-1]]
+type Box struct {
+	Min []float64
+	Max []float64
+}
+
+// CubeBox - гиперкуб [lo, hi]^n
+func CubeBox(n int, lo, hi float64) Box {
+	box := Box{Min: make([]float64, n), Max: make([]float64, n)}
+	for i := 0; i < n; i++ {
+		box.Min[i] = lo
+		box.Max[i] = hi
+	}
+	return box
+}
+
+// Dim - размерность параллелепипеда
+func (box Box) Dim() int {
+	return len(box.Min)
+}
+
+// Volume - объем параллелепипеда
+func (box Box) Volume() float64 {
+	v := 1.0
+	for i := range box.Min {
+		v *= box.Max[i] - box.Min[i]
+	}
+	return v
+}
+
+// MultiIntegral - интеграл функции f по области {x из box: inside(x)}
+// методом среднего значения. Если f == nil, интегрируется единица
+// (вычисляется объем области), если inside == nil - областью является
+// весь параллелепипед.
+func MultiIntegral(box Box, f func([]float64) float64, inside func([]float64) bool, expNmb int, level float64) MCResult {
+	var acc Accumulator
+
+	n := box.Dim()
+	volume := box.Volume()
+	x := make([]float64, n)
+	for i := 0; i < expNmb; i++ {
+		for j := 0; j < n; j++ {
+			x[j] = box.Min[j] + (box.Max[j]-box.Min[j])*rand.Float64()
+		}
+		if inside != nil && !inside(x) {
+			acc.Add(0)
+			continue
+		}
+		if f == nil {
+			acc.Add(volume)
+		} else {
+			acc.Add(volume * f(x))
+		}
+	}
+
+	return acc.Result(level)
+}
+
+// InBall - индикатор n-мерного шара радиуса r с центром center
+func InBall(center []float64, r float64) func([]float64) bool {
+	return func(x []float64) bool {
+		var d2 float64
+		for i := range x {
+			d2 += (x[i] - center[i]) * (x[i] - center[i])
+		}
+		return d2 < r*r
+	}
+}
+
+// InPolytope - индикатор выпуклого многогранника {x: A x <= b}
+func InPolytope(A [][]float64, b []float64) func([]float64) bool {
+	return func(x []float64) bool {
+		for i, row := range A {
+			var s float64
+			for j := range row {
+				s += row[j] * x[j]
+			}
+			if s > b[i] {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// BallVolume - точный объем n-мерного шара радиуса r:
+// V_n(r) = π^(n/2) r^n / Γ(n/2 + 1)
+func BallVolume(n int, r float64) float64 {
+	return math.Pow(math.Pi, float64(n)/2) * math.Pow(r, float64(n)) / math.Gamma(float64(n)/2+1)
+}
+
+// BallCheck - результат проверки многомерного интегрирования по объему шара
+type BallCheck struct {
+	Dim    int      // Размерность
+	Exact  float64  // Точный объем единичного шара
+	Result MCResult // Оценка методом Монте-Карло
+	InCI   bool     // Попадает ли точное значение в доверительный интервал
+}
+
+// CheckBallVolumes - самопроверка MultiIntegral: объемы единичных шаров
+// размерностей 2..maxDim, вычисленные в кубе [-1, 1]^n, сравниваются
+// с точными значениями
+func CheckBallVolumes(maxDim, expNmb int, level float64) []BallCheck {
+	checks := make([]BallCheck, 0, maxDim-1)
+	for n := 2; n <= maxDim; n++ {
+		center := make([]float64, n)
+		res := MultiIntegral(CubeBox(n, -1, 1), nil, InBall(center, 1), expNmb, level)
+		exact := BallVolume(n, 1)
+		checks = append(checks, BallCheck{
+			Dim:    n,
+			Exact:  exact,
+			Result: res,
+			InCI:   exact >= res.CILow && exact <= res.CIHigh,
+		})
+	}
+	return checks
+}
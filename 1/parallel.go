@@ -0,0 +1,127 @@
+package main
+
+import (
+	"math/rand"
+	randv2 "math/rand/v2"
+	"sync"
+)
+
+// Sampler - одно испытание метода Монте-Карло, использующее генератор rng.
+// Оценкой метода является среднее значение испытаний.
+type Sampler func(rng *rand.Rand) float64
+
+// PiSampler - испытание CALC_PI: 4, если точка попала в круг, иначе 0
+func PiSampler(x0, y0, r0 float64) Sampler {
+	return func(rng *rand.Rand) float64 {
+		xp := x0 - r0 + 2*r0*rng.Float64()
+		yp := y0 - r0 + 2*r0*rng.Float64()
+		if (xp-x0)*(xp-x0)+(yp-y0)*(yp-y0) < r0*r0 {
+			return 4
+		}
+		return 0
+	}
+}
+
+// IntegralSampler - испытание метода среднего значения: (b-a) f(x), x ~ U[a, b]
+func IntegralSampler(a, b float64, f func(float64) float64) Sampler {
+	return func(rng *rand.Rand) float64 {
+		return (b - a) * f(a+(b-a)*rng.Float64())
+	}
+}
+
+// HitOrMissSampler - испытание метода "попал/не попал" со знаком
+// в охватывающем прямоугольнике env
+func HitOrMissSampler(a, b float64, f func(float64) float64, env Envelope) Sampler {
+	area := (b - a) * (env.YMax - env.YMin)
+	return func(rng *rand.Rand) float64 {
+		x := a + (b-a)*rng.Float64()
+		y := env.YMin + (env.YMax-env.YMin)*rng.Float64()
+		fx := f(x)
+		if y >= 0 && y < fx {
+			return area
+		} else if y < 0 && y > fx {
+			return -area
+		}
+		return 0
+	}
+}
+
+// Количество испытаний в блоке с собственным потоком случайных чисел
+const parallelBlockSize = 1 << 16
+
+// StreamSeed - зерно независимого потока номер stream, полученное из
+// общего зерна seed перемешивающей функцией SplitMix64. Функция
+// взаимно однозначна по stream, поэтому зерна разных потоков различны.
+func StreamSeed(seed int64, stream uint64) uint64 {
+	z := uint64(seed) + (stream+1)*0x9E3779B97F4A7C15
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	return z ^ (z >> 31)
+}
+
+// pcgSource - источник для math/rand на основе 128-битного генератора PCG
+// из math/rand/v2. rand.NewSource приводит зерно по модулю 2^31-1, и зерна
+// разных потоков могли бы совпасть; здесь используются все 64 бита.
+type pcgSource struct {
+	pcg *randv2.PCG
+}
+
+func (s pcgSource) Uint64() uint64  { return s.pcg.Uint64() }
+func (s pcgSource) Int63() int64    { return int64(s.pcg.Uint64() >> 1) }
+func (s pcgSource) Seed(seed int64) { s.pcg.Seed(uint64(seed), 0) }
+
+// NewStreamRand - генератор потока номер stream: состояние PCG составлено
+// из общего зерна seed и StreamSeed(seed, stream), поэтому у разных
+// потоков начальные состояния гарантированно различны
+func NewStreamRand(seed int64, stream uint64) *rand.Rand {
+	return rand.New(pcgSource{randv2.NewPCG(uint64(seed), StreamSeed(seed, stream))})
+}
+
+// RunParallel - параллельный запуск expNmb испытаний на workers горутинах.
+// Испытания делятся на блоки фиксированного размера, у каждого блока свой
+// генератор NewStreamRand(seed, номер блока), а результаты блоков
+// объединяются в порядке номеров. Поэтому при одном и том же seed
+// результат не зависит от количества горутин, а глобальный генератор
+// math/rand (с блокировкой на каждом вызове) не используется.
+func RunParallel(sampler Sampler, expNmb, workers int, seed int64, level float64) MCResult {
+	if workers < 1 {
+		workers = 1
+	}
+	blocks := (expNmb + parallelBlockSize - 1) / parallelBlockSize
+	partial := make([]Accumulator, blocks)
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for blk := range jobs {
+				rng := NewStreamRand(seed, uint64(blk))
+				n := min(parallelBlockSize, expNmb-blk*parallelBlockSize)
+				var acc Accumulator
+				for i := 0; i < n; i++ {
+					acc.Add(sampler(rng))
+				}
+				partial[blk] = acc
+			}
+		}()
+	}
+	for blk := 0; blk < blocks; blk++ {
+		jobs <- blk
+	}
+	close(jobs)
+	wg.Wait()
+
+	var total Accumulator
+	for _, acc := range partial {
+		total.Merge(acc)
+	}
+	return total.Result(level)
+}
+
+// CALC_PI_PARALLEL - параллельный вариант CALC_PI_STATS с воспроизводимым
+// результатом для заданного зерна
+func CALC_PI_PARALLEL(x0, y0, r0 float64, expNmb, workers int, seed int64, level float64) MCResult {
+	return RunParallel(PiSampler(x0, y0, r0), expNmb, workers, seed, level)
+}
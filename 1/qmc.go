@@ -0,0 +1,280 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// PointSource - источник точек единичного гиперкуба [0, 1)^Dim:
+// псевдослучайный или квазислучайный (последовательность с низкой
+// дисперсностью)
+type PointSource interface {
+	Dim() int
+	Next(x []float64)
+}
+
+// ========== ПСЕВДОСЛУЧАЙНЫЕ ТОЧКИ ==========
+
+// RandomPoints - обычные псевдослучайные точки генератора rng
+type RandomPoints struct {
+	dim int
+	rng *rand.Rand
+}
+
+// NewRandomPoints - источник псевдослучайных точек размерности dim
+func NewRandomPoints(dim int, rng *rand.Rand) *RandomPoints {
+	return &RandomPoints{dim: dim, rng: rng}
+}
+
+func (rp *RandomPoints) Dim() int {
+	return rp.dim
+}
+
+func (rp *RandomPoints) Next(x []float64) {
+	for j := 0; j < rp.dim; j++ {
+		x[j] = rp.rng.Float64()
+	}
+}
+
+// ========== ПОСЛЕДОВАТЕЛЬНОСТЬ ХАЛТОНА ==========
+
+// Первые простые числа - основания последовательности Халтона по осям
+var haltonBases = []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47, 53}
+
+// Halton - последовательность Халтона: по оси j используется обращение
+// цифр номера точки в системе счисления с основанием haltonBases[j].
+// В перемешанном варианте каждая позиция цифры по каждой оси проходит
+// через свою случайную перестановку цифр.
+type Halton struct {
+	dim   int
+	index uint64
+	perms [][][]int // perms[j][k] - перестановка k-й цифры по оси j (nil без перемешивания)
+}
+
+// NewHalton - последовательность Халтона без перемешивания
+func NewHalton(dim int) *Halton {
+	if dim > len(haltonBases) {
+		panic(fmt.Sprintf("Halton: размерность %d больше максимальной %d", dim, len(haltonBases)))
+	}
+	return &Halton{dim: dim}
+}
+
+// NewScrambledHalton - последовательность Халтона со случайным перемешиванием
+// цифр. Перемешиваются все цифры до точности float64, поэтому каждая точка
+// равномерно распределена в [0, 1)^dim и оценки по ней несмещенные.
+func NewScrambledHalton(dim int, rng *rand.Rand) *Halton {
+	h := NewHalton(dim)
+	h.perms = make([][][]int, dim)
+	for j := 0; j < dim; j++ {
+		base := haltonBases[j]
+		digits := int(math.Ceil(53 / math.Log2(float64(base))))
+		h.perms[j] = make([][]int, digits)
+		for k := range h.perms[j] {
+			h.perms[j][k] = rng.Perm(base)
+		}
+	}
+	return h
+}
+
+func (h *Halton) Dim() int {
+	return h.dim
+}
+
+func (h *Halton) Next(x []float64) {
+	for j := 0; j < h.dim; j++ {
+		base := haltonBases[j]
+		inv := 1 / float64(base)
+		scale := inv
+		var v float64
+		n := h.index
+		if h.perms == nil {
+			for n > 0 {
+				v += float64(n%uint64(base)) * scale
+				n /= uint64(base)
+				scale *= inv
+			}
+		} else {
+			// Нулевые старшие цифры тоже перемешиваются
+			for k := range h.perms[j] {
+				v += float64(h.perms[j][k][n%uint64(base)]) * scale
+				n /= uint64(base)
+				scale *= inv
+			}
+		}
+		x[j] = v
+	}
+	h.index++
+}
+
+// ========== ПОСЛЕДОВАТЕЛЬНОСТЬ СОБОЛЯ ==========
+
+// Количество двоичных разрядов точек последовательности Соболя
+const sobolBits = 32
+
+// sobolParams - примитивный многочлен степени s (коэффициенты a) и начальные
+// направляющие числа m_1..m_s (S. Joe, F. Y. Kuo, new-joe-kuo-6.21201)
+type sobolParams struct {
+	s int
+	a uint32
+	m []uint32
+}
+
+// Параметры для осей 2..16; первая ось - последовательность ван дер Корпута
+var sobolTable = []sobolParams{
+	{1, 0, []uint32{1}},
+	{2, 1, []uint32{1, 3}},
+	{3, 1, []uint32{1, 3, 1}},
+	{3, 2, []uint32{1, 1, 1}},
+	{4, 1, []uint32{1, 1, 3, 3}},
+	{4, 4, []uint32{1, 3, 5, 13}},
+	{5, 2, []uint32{1, 1, 5, 5, 17}},
+	{5, 4, []uint32{1, 1, 5, 5, 5}},
+	{5, 7, []uint32{1, 1, 7, 11, 19}},
+	{5, 11, []uint32{1, 1, 5, 1, 1}},
+	{5, 13, []uint32{1, 1, 1, 3, 11}},
+	{5, 14, []uint32{1, 3, 5, 5, 31}},
+	{6, 1, []uint32{1, 3, 3, 9, 7, 49}},
+	{6, 13, []uint32{1, 1, 1, 15, 21, 21}},
+	{6, 16, []uint32{1, 3, 1, 13, 27, 49}},
+}
+
+// Sobol - последовательность Соболя (построение в коде Грея).
+// В рандомизированном варианте к точкам применяется случайный
+// цифровой сдвиг (XOR со случайным числом по каждой оси), который
+// сохраняет структуру сети и делает каждую точку равномерной.
+type Sobol struct {
+	dim   int
+	index uint32
+	v     [][sobolBits]uint32 // Направляющие числа v[j][k] = m_k * 2^(32-k)
+	x     []uint32            // Текущая точка
+	shift []uint32            // Цифровой сдвиг (нули без рандомизации)
+}
+
+// NewSobol - последовательность Соболя размерности dim (не более 16)
+func NewSobol(dim int) *Sobol {
+	if dim > len(sobolTable)+1 {
+		panic(fmt.Sprintf("Sobol: размерность %d больше максимальной %d", dim, len(sobolTable)+1))
+	}
+
+	sb := &Sobol{
+		dim:   dim,
+		v:     make([][sobolBits]uint32, dim),
+		x:     make([]uint32, dim),
+		shift: make([]uint32, dim),
+	}
+
+	for k := 0; k < sobolBits; k++ {
+		sb.v[0][k] = 1 << (sobolBits - 1 - k)
+	}
+	for j := 1; j < dim; j++ {
+		p := sobolTable[j-1]
+		for k := 0; k < p.s && k < sobolBits; k++ {
+			sb.v[j][k] = p.m[k] << (sobolBits - 1 - k)
+		}
+		for k := p.s; k < sobolBits; k++ {
+			v := sb.v[j][k-p.s] ^ (sb.v[j][k-p.s] >> p.s)
+			for i := 1; i < p.s; i++ {
+				if (p.a>>(p.s-1-i))&1 == 1 {
+					v ^= sb.v[j][k-i]
+				}
+			}
+			sb.v[j][k] = v
+		}
+	}
+	return sb
+}
+
+// NewShiftedSobol - последовательность Соболя со случайным цифровым сдвигом
+func NewShiftedSobol(dim int, rng *rand.Rand) *Sobol {
+	sb := NewSobol(dim)
+	for j := range sb.shift {
+		sb.shift[j] = rng.Uint32()
+	}
+	return sb
+}
+
+func (sb *Sobol) Dim() int {
+	return sb.dim
+}
+
+func (sb *Sobol) Next(x []float64) {
+	const scale = 1.0 / (1 << sobolBits)
+	for j := 0; j < sb.dim; j++ {
+		x[j] = float64(sb.x[j]^sb.shift[j]) * scale
+	}
+
+	// Переход к следующей точке: номер меняющегося разряда равен
+	// номеру младшего нулевого бита текущего индекса
+	c := 0
+	for n := sb.index; n&1 == 1; n >>= 1 {
+		c++
+	}
+	for j := 0; j < sb.dim; j++ {
+		sb.x[j] ^= sb.v[j][c]
+	}
+	sb.index++
+}
+
+// ========== ОЦЕНКИ ПО ПРОИЗВОЛЬНОМУ ИСТОЧНИКУ ТОЧЕК ==========
+
+// CALC_PI_QMC - вариант CALC_PI, в котором точки берутся из двумерного
+// источника src вместо генератора math/rand
+func CALC_PI_QMC(x0, y0, r0 float64, src PointSource, expNmb int) float64 {
+	var m int = 0
+	var xp, yp float64
+	u := make([]float64, 2)
+	for i := 0; i < expNmb; i++ {
+		src.Next(u)
+		xp = x0 - r0 + 2*r0*u[0]
+		yp = y0 - r0 + 2*r0*u[1]
+		if (xp-x0)*(xp-x0)+(yp-y0)*(yp-y0) < r0*r0 {
+			m++
+		}
+	}
+
+	return 4 * (float64(m) / float64(expNmb))
+}
+
+// CALC_INTEGRAL_QMC - вариант CALC_INTEGRAL (прямоугольник [0, f(b)])
+// с точками из двумерного источника src
+func CALC_INTEGRAL_QMC(a, b float64, f func(float64) float64, src PointSource, expNmb int) float64 {
+	var m int = 0
+	var x, y float64
+	u := make([]float64, 2)
+	for i := 0; i < expNmb; i++ {
+		src.Next(u)
+		x = (b-a)*u[0] + a
+		y = f(b) * u[1]
+		if f(x) > y {
+			m++
+		}
+	}
+
+	return (float64(m) / float64(expNmb)) * (b - a) * f(b)
+}
+
+// SampleMeanIntegralQMC - метод среднего значения с точками из
+// одномерного источника src
+func SampleMeanIntegralQMC(a, b float64, f func(float64) float64, src PointSource, expNmb int) float64 {
+	var sum float64
+	u := make([]float64, 1)
+	for i := 0; i < expNmb; i++ {
+		src.Next(u)
+		sum += f(a + (b-a)*u[0])
+	}
+	return (b - a) * sum / float64(expNmb)
+}
+
+// RandomizedQMC - оценка погрешности квазислучайного метода: estimator
+// запускается replications раз на независимо рандомизированных
+// последовательностях newSource, результаты повторений считаются
+// независимыми испытаниями
+func RandomizedQMC(replications int, newSource func(rng *rand.Rand) PointSource,
+	estimator func(src PointSource) float64, rng *rand.Rand, level float64) MCResult {
+	var acc Accumulator
+	for r := 0; r < replications; r++ {
+		acc.Add(estimator(newSource(rng)))
+	}
+	return acc.Result(level)
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"container/heap"
+	"math"
+)
+
+// QuadResult - результат детерминированного численного интегрирования
+type QuadResult struct {
+	Value       float64 // Значение интеграла
+	AbsErr      float64 // Оценка абсолютной погрешности
+	Evaluations int     // Количество вычислений функции
+}
+
+// ========== ФОРМУЛА СИМПСОНА ==========
+
+// simpson - составная формула Симпсона на n (четном) отрезках
+func simpson(a, b float64, f func(float64) float64, n int) float64 {
+	h := (b - a) / float64(n)
+	sum := f(a) + f(b)
+	for i := 1; i < n; i++ {
+		if i%2 == 1 {
+			sum += 4 * f(a+float64(i)*h)
+		} else {
+			sum += 2 * f(a+float64(i)*h)
+		}
+	}
+	return sum * h / 3
+}
+
+// Simpson - составная формула Симпсона на n отрезках (n округляется вверх
+// до кратного 4); погрешность оценивается по правилу Рунге сравнением с
+// результатом на n/2 отрезках
+func Simpson(a, b float64, f func(float64) float64, n int) QuadResult {
+	if n < 4 {
+		n = 4
+	}
+	n = (n + 3) / 4 * 4 // n и n/2 должны быть четными
+	fine := simpson(a, b, f, n)
+	coarse := simpson(a, b, f, n/2)
+	return QuadResult{
+		Value:       fine,
+		AbsErr:      math.Abs(fine-coarse) / 15,
+		Evaluations: n + 1 + n/2 + 1,
+	}
+}
+
+// ========== КВАДРАТУРА ГАУССА - ЛЕЖАНДРА ==========
+
+// GaussLegendreNodes - узлы и веса n-точечной квадратуры Гаусса - Лежандра
+// на [-1, 1]: узлы - корни многочлена Лежандра P_n, найденные методом Ньютона
+func GaussLegendreNodes(n int) (nodes, weights []float64) {
+	nodes = make([]float64, n)
+	weights = make([]float64, n)
+	for i := 0; i < (n+1)/2; i++ {
+		x := math.Cos(math.Pi * (float64(i) + 0.75) / (float64(n) + 0.5))
+		var dp float64
+		for iter := 0; iter < 100; iter++ {
+			// P_n(x) и P_n'(x) по рекуррентной формуле
+			p0, p1 := 1.0, x
+			for k := 2; k <= n; k++ {
+				p0, p1 = p1, ((2*float64(k)-1)*x*p1-(float64(k)-1)*p0)/float64(k)
+			}
+			if n == 1 {
+				p0, p1 = 1, x
+			}
+			dp = float64(n) * (x*p1 - p0) / (x*x - 1)
+			dx := p1 / dp
+			x -= dx
+			if math.Abs(dx) < 1e-15 {
+				break
+			}
+		}
+		// Пересчитываем производную в уточненном узле
+		p0, p1 := 1.0, x
+		for k := 2; k <= n; k++ {
+			p0, p1 = p1, ((2*float64(k)-1)*x*p1-(float64(k)-1)*p0)/float64(k)
+		}
+		if n == 1 {
+			p0, p1 = 1, x
+		}
+		dp = float64(n) * (x*p1 - p0) / (x*x - 1)
+
+		nodes[i] = -x
+		nodes[n-1-i] = x
+		weights[i] = 2 / ((1 - x*x) * dp * dp)
+		weights[n-1-i] = weights[i]
+	}
+	return nodes, weights
+}
+
+// gaussLegendre - n-точечная квадратура на panels равных отрезках
+func gaussLegendre(a, b float64, f func(float64) float64, nodes, weights []float64, panels int) float64 {
+	h := (b - a) / float64(panels)
+	var sum float64
+	for p := 0; p < panels; p++ {
+		mid := a + (float64(p)+0.5)*h
+		for i := range nodes {
+			sum += weights[i] * f(mid+h/2*nodes[i])
+		}
+	}
+	return sum * h / 2
+}
+
+// GaussLegendre - составная квадратура Гаусса - Лежандра с n узлами на
+// каждом из panels отрезков; погрешность оценивается сравнением с
+// результатом на вдвое меньшем количестве отрезков
+func GaussLegendre(a, b float64, f func(float64) float64, n, panels int) QuadResult {
+	if panels < 2 {
+		panels = 2
+	}
+	nodes, weights := GaussLegendreNodes(n)
+	fine := gaussLegendre(a, b, f, nodes, weights, panels)
+	coarse := gaussLegendre(a, b, f, nodes, weights, panels/2)
+	return QuadResult{
+		Value:       fine,
+		AbsErr:      math.Abs(fine - coarse),
+		Evaluations: n * (panels + panels/2),
+	}
+}
+
+// ========== АДАПТИВНАЯ КВАДРАТУРА ГАУССА - КРОНРОДА ==========
+
+// Узлы и веса правила Гаусса - Кронрода G7-K15 на [-1, 1] (QUADPACK):
+// xgk[1], xgk[3], xgk[5], xgk[7] - узлы правила Гаусса с весами wg
+var (
+	xgk = [8]float64{
+		0.991455371120812639206854697526329,
+		0.949107912342758524526189684047851,
+		0.864864423359769072789712788640926,
+		0.741531185599394439863864773280788,
+		0.586087235467691130294144845693013,
+		0.405845151377397166906606412076961,
+		0.207784955007898467600689403773245,
+		0.000000000000000000000000000000000,
+	}
+	wgk = [8]float64{
+		0.022935322010529224963732008058970,
+		0.063092092629978553290700663189204,
+		0.104790010322250183839876322541518,
+		0.140653259715525918745189590510238,
+		0.169004726639267902826583426598550,
+		0.190350578064785409913256402421014,
+		0.204432940075298892414161999234649,
+		0.209482141084727828012999174891714,
+	}
+	wg = [4]float64{
+		0.129484966168869693270611432679082,
+		0.279705391489276667901467771423780,
+		0.381830050505118944950369775488975,
+		0.417959183673469387755102040816327,
+	}
+)
+
+// gk15 - интеграл по правилу Кронрода и разность с правилом Гаусса
+// на отрезке [a, b]
+func gk15(a, b float64, f func(float64) float64) (value, absErr float64) {
+	c := (a + b) / 2
+	h := (b - a) / 2
+
+	fc := f(c)
+	kronrod := wgk[7] * fc
+	gauss := wg[3] * fc
+	for j := 0; j < 7; j++ {
+		dx := h * xgk[j]
+		sum := f(c-dx) + f(c+dx)
+		kronrod += wgk[j] * sum
+		if j%2 == 1 {
+			gauss += wg[j/2] * sum
+		}
+	}
+	return kronrod * h, math.Abs((kronrod - gauss) * h)
+}
+
+// quadInterval - отрезок адаптивного разбиения
+type quadInterval struct {
+	a, b   float64
+	value  float64
+	absErr float64
+}
+
+// quadHeap - очередь отрезков по убыванию оценки погрешности
+type quadHeap []quadInterval
+
+func (h quadHeap) Len() int           { return len(h) }
+func (h quadHeap) Less(i, j int) bool { return h[i].absErr > h[j].absErr }
+func (h quadHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *quadHeap) Push(x any)        { *h = append(*h, x.(quadInterval)) }
+func (h *quadHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// AdaptiveGaussKronrod - глобально адаптивная квадратура G7-K15:
+// отрезок с наибольшей оценкой погрешности делится пополам, пока суммарная
+// погрешность больше max(absTol, relTol*|I|) и не превышен лимит maxIntervals
+func AdaptiveGaussKronrod(a, b float64, f func(float64) float64, absTol, relTol float64, maxIntervals int) QuadResult {
+	value, absErr := gk15(a, b, f)
+	h := &quadHeap{{a: a, b: b, value: value, absErr: absErr}}
+	res := QuadResult{Value: value, AbsErr: absErr, Evaluations: 15}
+
+	for h.Len() < maxIntervals && res.AbsErr > math.Max(absTol, relTol*math.Abs(res.Value)) {
+		worst := heap.Pop(h).(quadInterval)
+		mid := (worst.a + worst.b) / 2
+		v1, e1 := gk15(worst.a, mid, f)
+		v2, e2 := gk15(mid, worst.b, f)
+		res.Evaluations += 30
+
+		res.Value += v1 + v2 - worst.value
+		res.AbsErr += e1 + e2 - worst.absErr
+		heap.Push(h, quadInterval{a: worst.a, b: mid, value: v1, absErr: e1})
+		heap.Push(h, quadInterval{a: mid, b: worst.b, value: v2, absErr: e2})
+	}
+
+	// Пересчитываем суммы заново, чтобы не накапливать ошибки округления
+	res.Value, res.AbsErr = 0, 0
+	for _, iv := range *h {
+		res.Value += iv.value
+		res.AbsErr += iv.absErr
+	}
+	return res
+}
+
+// ReferenceIntegral - эталонное значение интеграла для расчета погрешностей
+// методов Монте-Карло (адаптивная квадратура Гаусса - Кронрода)
+func ReferenceIntegral(a, b float64, f func(float64) float64) QuadResult {
+	return AdaptiveGaussKronrod(a, b, f, 1e-13, 1e-13, 10000)
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Уровень доверия по умолчанию для доверительных интервалов
+const DefaultLevel = 0.95
+
+// MCResult - результат оценки методом Монте-Карло со статистикой погрешности
+type MCResult struct {
+	Estimate float64 // Оценка искомой величины (среднее по испытаниям)
+	Variance float64 // Выборочная (несмещенная) дисперсия одного испытания
+	StdErr   float64 // Стандартная ошибка оценки: sqrt(Variance/ExpNmb)
+	Level    float64 // Уровень доверия
+	CILow    float64 // Нижняя граница доверительного интервала
+	CIHigh   float64 // Верхняя граница доверительного интервала
+	ExpNmb   int     // Количество испытаний
+}
+
+// HalfWidth - полуширина доверительного интервала
+func (r MCResult) HalfWidth() float64 {
+	return (r.CIHigh - r.CILow) / 2
+}
+
+// Accumulator - накопитель выборочного среднего и дисперсии
+// по алгоритму Уэлфорда (устойчив к накоплению ошибок округления)
+type Accumulator struct {
+	n    int
+	mean float64
+	m2   float64
+}
+
+// Add - добавление результата одного испытания
+func (acc *Accumulator) Add(v float64) {
+	acc.n++
+	d := v - acc.mean
+	acc.mean += d / float64(acc.n)
+	acc.m2 += d * (v - acc.mean)
+}
+
+// Merge - объединение с накопителем other (формула Чана для
+// параллельного вычисления дисперсии)
+func (acc *Accumulator) Merge(other Accumulator) {
+	if other.n == 0 {
+		return
+	}
+	if acc.n == 0 {
+		*acc = other
+		return
+	}
+	n := acc.n + other.n
+	d := other.mean - acc.mean
+	acc.mean += d * float64(other.n) / float64(n)
+	acc.m2 += other.m2 + d*d*float64(acc.n)*float64(other.n)/float64(n)
+	acc.n = n
+}
+
+// Count - количество учтенных испытаний
+func (acc *Accumulator) Count() int {
+	return acc.n
+}
+
+// Mean - текущее выборочное среднее
+func (acc *Accumulator) Mean() float64 {
+	return acc.mean
+}
+
+// Variance - текущая несмещенная выборочная дисперсия
+func (acc *Accumulator) Variance() float64 {
+	if acc.n < 2 {
+		return 0
+	}
+	return acc.m2 / float64(acc.n-1)
+}
+
+// Result - оценка с доверительным интервалом заданного уровня,
+// построенным по нормальному приближению (ЦПТ)
+func (acc *Accumulator) Result(level float64) MCResult {
+	return newMCResult(acc.mean, acc.Variance(), acc.n, level)
+}
+
+// newMCResult - заполнение результата по оценке, дисперсии одного
+// испытания и количеству испытаний
+func newMCResult(estimate, variance float64, n int, level float64) MCResult {
+	res := MCResult{
+		Estimate: estimate,
+		Variance: variance,
+		Level:    level,
+		ExpNmb:   n,
+	}
+	if n > 0 {
+		res.StdErr = math.Sqrt(variance / float64(n))
+	}
+	z := NormalQuantile(0.5 + level/2)
+	res.CILow = res.Estimate - z*res.StdErr
+	res.CIHigh = res.Estimate + z*res.StdErr
+	return res
+}
+
+// NormalQuantile - квантиль стандартного нормального распределения уровня p
+func NormalQuantile(p float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
+
+// CALC_PI_STATS - вариант CALC_PI, возвращающий оценку числа Пи вместе
+// с дисперсией, стандартной ошибкой и доверительным интервалом
+func CALC_PI_STATS(x0, y0, r0 float64, expNmb int, level float64) MCResult {
+	var acc Accumulator
+
+	var p, xp, yp float64
+	for i := 0; i < expNmb; i++ {
+		p = rand.Float64()
+		xp = x0 - r0 + 2*r0*p
+		p = rand.Float64()
+		yp = y0 - r0 + 2*r0*p
+		if (xp-x0)*(xp-x0)+(yp-y0)*(yp-y0) < r0*r0 {
+			acc.Add(4)
+		} else {
+			acc.Add(0)
+		}
+	}
+
+	return acc.Result(level)
+}
+
+// CALC_INTEGRAL_STATS - вариант CALC_INTEGRAL (прямоугольник [0, f(b)])
+// со статистикой погрешности
+func CALC_INTEGRAL_STATS(a, b float64, f func(float64) float64, expNmb int, level float64) MCResult {
+	var acc Accumulator
+
+	area := (b - a) * f(b)
+	var x, y float64
+	for i := 0; i < expNmb; i++ {
+		x = a + (b-a)*rand.Float64()
+		y = f(b) * rand.Float64()
+		if f(x) > y {
+			acc.Add(area)
+		} else {
+			acc.Add(0)
+		}
+	}
+
+	return acc.Result(level)
+}
+
+// SampleMeanIntegral - интегрирование методом среднего значения
+// (простой метод Монте-Карло): I ≈ (b-a) * среднее f(x_i), x_i ~ U[a, b]
+func SampleMeanIntegral(a, b float64, f func(float64) float64, expNmb int, level float64) MCResult {
+	var acc Accumulator
+
+	for i := 0; i < expNmb; i++ {
+		acc.Add((b - a) * f(a+(b-a)*rand.Float64()))
+	}
+
+	return acc.Result(level)
+}
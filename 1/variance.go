@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// VRResult - результат интегрирования с понижением дисперсии.
+// Коэффициент снижения дисперсии показывает, во сколько раз квадрат
+// стандартной ошибки простого метода среднего значения больше, чем у
+// данного метода при одинаковом количестве вычислений функции.
+type VRResult struct {
+	MCResult
+	Evaluations   int     // Количество вычислений подынтегральной функции
+	PlainVariance float64 // Оценка дисперсии одного испытания простого метода
+	Reduction     float64 // Коэффициент снижения дисперсии
+}
+
+// newVRResult - вычисление коэффициента снижения дисперсии по результату
+// метода и оценке дисперсии простого метода на тех же точках
+func newVRResult(res MCResult, evaluations int, plainVariance float64) VRResult {
+	vr := VRResult{MCResult: res, Evaluations: evaluations, PlainVariance: plainVariance}
+	if res.StdErr > 0 {
+		vr.Reduction = plainVariance / (float64(evaluations) * res.StdErr * res.StdErr)
+	} else {
+		vr.Reduction = math.Inf(1)
+	}
+	return vr
+}
+
+// AntitheticIntegral - метод антитетических переменных: точки берутся
+// парами x и a+b-x, испытанием считается среднее значение по паре
+func AntitheticIntegral(a, b float64, f func(float64) float64, expNmb int, level float64) VRResult {
+	var acc, plain Accumulator
+
+	pairs := expNmb / 2
+	var x, g1, g2 float64
+	for i := 0; i < pairs; i++ {
+		x = a + (b-a)*rand.Float64()
+		g1 = (b - a) * f(x)
+		g2 = (b - a) * f(a+b-x)
+		acc.Add((g1 + g2) / 2)
+		plain.Add(g1)
+		plain.Add(g2)
+	}
+
+	return newVRResult(acc.Result(level), 2*pairs, plain.Variance())
+}
+
+// ControlVariateIntegral - метод контрольных переменных: из оценки
+// вычитается отклонение оценки интеграла контрольной функции h от его
+// известного значения hIntegral с оптимальным коэффициентом,
+// оцененным по той же выборке
+func ControlVariateIntegral(a, b float64, f, h func(float64) float64, hIntegral float64, expNmb int, level float64) VRResult {
+	// Совместные моменты по алгоритму Уэлфорда
+	var n int
+	var meanG, meanK, sGG, sKK, sGK float64
+
+	var x, g, k, dG, dK float64
+	for i := 0; i < expNmb; i++ {
+		x = a + (b-a)*rand.Float64()
+		g = (b - a) * f(x)
+		k = (b - a) * h(x)
+
+		n++
+		dG = g - meanG
+		dK = k - meanK
+		meanG += dG / float64(n)
+		meanK += dK / float64(n)
+		sGG += dG * (g - meanG)
+		sKK += dK * (k - meanK)
+		sGK += dG * (k - meanK)
+	}
+
+	var c float64
+	if sKK > 0 {
+		c = sGK / sKK
+	}
+	estimate := meanG - c*(meanK-hIntegral)
+
+	// Остаточная дисперсия после вычитания контрольной переменной
+	// (две степени свободы тратятся на среднее и коэффициент)
+	var variance, plainVariance float64
+	if n > 2 {
+		variance = (sGG - c*sGK) / float64(n-2)
+		plainVariance = sGG / float64(n-1)
+	}
+
+	return newVRResult(newMCResult(estimate, variance, n, level), n, plainVariance)
+}
+
+// StratifiedIntegral - метод расслоенной выборки: [a, b] делится на
+// strata равных слоев, в каждом берется одинаковое число точек
+// (пропорциональное размещение). Для оценки дисперсии в каждом слое
+// нужно не меньше двух точек, поэтому expNmb >= 2*strata.
+func StratifiedIntegral(a, b float64, f func(float64) float64, strata, expNmb int, level float64) (VRResult, error) {
+	if strata < 1 {
+		return VRResult{}, fmt.Errorf("некорректное количество слоев: %d", strata)
+	}
+	if expNmb < 2*strata {
+		return VRResult{}, fmt.Errorf("на %d слоев нужно не меньше %d испытаний, задано %d", strata, 2*strata, expNmb)
+	}
+	perStratum := expNmb / strata
+	h := (b - a) / float64(strata)
+
+	var estimate, estVariance, secondMoment float64
+	for k := 0; k < strata; k++ {
+		var acc Accumulator
+		lo := a + float64(k)*h
+		for i := 0; i < perStratum; i++ {
+			acc.Add(f(lo + h*rand.Float64()))
+		}
+
+		mean := acc.Mean()
+		estimate += h * mean
+		estVariance += h * h * acc.Variance() / float64(perStratum)
+
+		// Второй момент f по всему отрезку - для оценки дисперсии простого метода
+		meanSq := acc.Variance()*float64(perStratum-1)/float64(perStratum) + mean*mean
+		secondMoment += meanSq / float64(strata)
+	}
+
+	n := strata * perStratum
+	integralMean := estimate / (b - a)
+	plainVariance := (b - a) * (b - a) * (secondMoment - integralMean*integralMean)
+
+	return newVRResult(newMCResult(estimate, estVariance*float64(n), n, level), n, plainVariance), nil
+}
+
+// ImportanceIntegral - метод выборки по значимости: точки генерируются
+// с плотностью pdf на [a, b] методом обратной функции invCDF, испытанием
+// является отношение f(x)/pdf(x)
+func ImportanceIntegral(a, b float64, f, pdf, invCDF func(float64) float64, expNmb int, level float64) VRResult {
+	var acc, plainSq Accumulator
+
+	var x, fx, w float64
+	for i := 0; i < expNmb; i++ {
+		x = invCDF(rand.Float64())
+		fx = f(x)
+		w = fx / pdf(x)
+		acc.Add(w)
+
+		// E_u[((b-a)f)^2] = E_p[(b-a) f^2 / p] - второй момент простого метода
+		plainSq.Add((b - a) * fx * w)
+	}
+
+	res := acc.Result(level)
+	plainVariance := plainSq.Mean() - res.Estimate*res.Estimate
+
+	return newVRResult(res, expNmb, plainVariance)
+}
@@ -0,0 +1,342 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"labs/stats"
+)
+
+// UniformSource - источник равномерно распределенных на [0, 1) чисел,
+// например метод Float64 генератора LCG или rand.Float64
+type UniformSource func() float64
+
+// TestResult - результат одного эмпирического теста
+type TestResult struct {
+	Name      string  // Название теста
+	Statistic float64 // Значение статистики
+	PValue    float64 // p-значение
+	Pass      bool    // Тест пройден (PValue >= alpha)
+}
+
+// BatteryConfig - параметры батареи тестов
+type BatteryConfig struct {
+	N     int     // Количество чисел, используемых каждым тестом
+	Alpha float64 // Уровень значимости
+	Lags  []int   // Сдвиги для теста автокорреляции
+}
+
+// DefaultBatteryConfig - параметры по умолчанию: 10^5 чисел на тест
+var DefaultBatteryConfig = BatteryConfig{N: 100000, Alpha: 0.01, Lags: []int{1, 2, 5, 10}}
+
+// RunBattery - батарея эмпирических тестов (Кнут, т. 2, разд. 3.3.2;
+// Марсалья, DIEHARD). Каждый тест использует очередные cfg.N чисел src.
+func RunBattery(src UniformSource, cfg BatteryConfig) []TestResult {
+	tests := []struct {
+		name string
+		run  func(src UniformSource, n int) (float64, float64)
+	}{
+		{"Серийный (пары, 16x16)", func(src UniformSource, n int) (float64, float64) { return SerialTest(src, n, 2, 16) }},
+		{"Серийный (тройки, 8x8x8)", func(src UniformSource, n int) (float64, float64) { return SerialTest(src, n, 3, 8) }},
+		{"Интервалов [0; 0.5)", func(src UniformSource, n int) (float64, float64) { return GapTest(src, n, 0, 0.5, 10) }},
+		{"Покер (5 цифр)", PokerTest},
+		{"Собирателя купонов (d=5)", CouponTest},
+		{"Серий вверх/вниз", RunsUpDownTest},
+		{"Перестановок (t=5)", PermutationTest},
+		{"Максимума из t=8", MaxOfTTest},
+		{"Дней рождения", BirthdaySpacingsTest},
+	}
+
+	var results []TestResult
+	for _, t := range tests {
+		stat, p := t.run(src, cfg.N)
+		results = append(results, TestResult{Name: t.name, Statistic: stat, PValue: p, Pass: p >= cfg.Alpha})
+	}
+	for _, lag := range cfg.Lags {
+		stat, p := AutocorrelationTest(src, cfg.N, lag)
+		results = append(results, TestResult{
+			Name: fmt.Sprintf("Автокорреляции (сдвиг %d)", lag), Statistic: stat, PValue: p, Pass: p >= cfg.Alpha,
+		})
+	}
+	return results
+}
+
+// PrintBattery - сводная таблица результатов батареи тестов
+func PrintBattery(name string, results []TestResult) {
+	fmt.Printf("Батарея тестов для генератора %s:\n", name)
+	fmt.Printf("%-30s %14s %10s %10s\n", "Тест", "Статистика", "p", "Результат")
+	passed := 0
+	for _, r := range results {
+		verdict := "не пройден"
+		if r.Pass {
+			verdict = "пройден"
+			passed++
+		}
+		fmt.Printf("%-30s %14.4f %10.4f %10s\n", r.Name, r.Statistic, r.PValue, verdict)
+	}
+	fmt.Printf("Пройдено тестов: %d из %d\n", passed, len(results))
+}
+
+// chiSquare - статистика и p-значение критерия Пирсона (без отклонения
+// гипотезы: решение принимает батарея)
+func chiSquare(observed, probs []float64) (float64, float64) {
+	res, err := ChiSquareTest(observed, probs, 0, 0)
+	if err != nil {
+		return math.NaN(), 0
+	}
+	return res.Statistic, res.PValue
+}
+
+// normalPValue - двустороннее p-значение стандартной нормальной статистики
+func normalPValue(z float64) float64 {
+	return math.Erfc(math.Abs(z) / math.Sqrt2)
+}
+
+// stirling2 - числа Стирлинга второго рода S(n, k)
+func stirling2(n, k int) float64 {
+	s := make([][]float64, n+1)
+	for i := range s {
+		s[i] = make([]float64, k+1)
+	}
+	s[0][0] = 1
+	for i := 1; i <= n; i++ {
+		for j := 1; j <= min(i, k); j++ {
+			s[i][j] = float64(j)*s[i-1][j] + s[i-1][j-1]
+		}
+	}
+	return s[n][k]
+}
+
+// digit - целое число из [0, d), полученное из равномерного числа
+func digit(u float64, d int) int {
+	return min(int(u*float64(d)), d-1)
+}
+
+// SerialTest - серийный тест: неперекрывающиеся наборы из dim чисел
+// распределяются по d^dim ячейкам, которые должны быть равновероятны
+func SerialTest(src UniformSource, n, dim, d int) (float64, float64) {
+	cells := 1
+	for i := 0; i < dim; i++ {
+		cells *= d
+	}
+	counts := make([]float64, cells)
+	for i := 0; i < n/dim; i++ {
+		idx := 0
+		for j := 0; j < dim; j++ {
+			idx = idx*d + digit(src(), d)
+		}
+		counts[idx]++
+	}
+	return chiSquare(counts, UniformProbs(cells))
+}
+
+// GapTest - тест интервалов: длины промежутков между попаданиями
+// в [alpha, beta) имеют геометрическое распределение; длины от t
+// и больше объединяются
+func GapTest(src UniformSource, n int, alpha, beta float64, t int) (float64, float64) {
+	p := beta - alpha
+	counts := make([]float64, t+1)
+	gap := 0
+	for i := 0; i < n; i++ {
+		u := src()
+		if u >= alpha && u < beta {
+			counts[min(gap, t)]++
+			gap = 0
+		} else {
+			gap++
+		}
+	}
+	probs := make([]float64, t+1)
+	for r := 0; r < t; r++ {
+		probs[r] = p * math.Pow(1-p, float64(r))
+	}
+	probs[t] = math.Pow(1-p, float64(t))
+	return chiSquare(counts, probs)
+}
+
+// PokerTest - покер-тест (упрощенный вариант Кнута): в группах из 5 цифр
+// от 0 до 9 считается количество различных цифр
+func PokerTest(src UniformSource, n int) (float64, float64) {
+	const k, d = 5, 10
+	counts := make([]float64, k+1)
+	for i := 0; i < n/k; i++ {
+		var seen [d]bool
+		distinct := 0
+		for j := 0; j < k; j++ {
+			c := digit(src(), d)
+			if !seen[c] {
+				seen[c] = true
+				distinct++
+			}
+		}
+		counts[distinct]++
+	}
+	// P(r различных) = d(d-1)...(d-r+1) / d^k * S(k, r)
+	probs := make([]float64, k+1)
+	for r := 1; r <= k; r++ {
+		falling := 1.0
+		for j := 0; j < r; j++ {
+			falling *= float64(d - j)
+		}
+		probs[r] = falling / math.Pow(d, k) * stirling2(k, r)
+	}
+	return chiSquare(counts[1:], probs[1:])
+}
+
+// CouponTest - тест собирателя купонов: длина отрезка, содержащего все
+// d цифр; длины от t и больше объединяются
+func CouponTest(src UniformSource, n int) (float64, float64) {
+	const d, t = 5, 30
+	counts := make([]float64, t+1)
+	used := 0
+	for used < n {
+		var seen [d]bool
+		distinct, length := 0, 0
+		for distinct < d && used < n {
+			c := digit(src(), d)
+			used++
+			length++
+			if !seen[c] {
+				seen[c] = true
+				distinct++
+			}
+		}
+		if distinct == d {
+			counts[min(length, t)]++
+		}
+	}
+	// P(длина = r) = d!/d^r S(r-1, d-1), P(длина >= t) = 1 - d!/d^(t-1) S(t-1, d)
+	fact := 1.0
+	for j := 2; j <= d; j++ {
+		fact *= float64(j)
+	}
+	probs := make([]float64, t+1)
+	for r := d; r < t; r++ {
+		probs[r] = fact / math.Pow(d, float64(r)) * stirling2(r-1, d-1)
+	}
+	probs[t] = 1 - fact/math.Pow(d, t-1)*stirling2(t-1, d)
+	return chiSquare(counts[d:], probs[d:])
+}
+
+// RunsUpDownTest - тест серий вверх и вниз: количество монотонных участков
+// R в последовательности из n чисел асимптотически нормально с
+// M[R] = (2n-1)/3, D[R] = (16n-29)/90
+func RunsUpDownTest(src UniformSource, n int) (float64, float64) {
+	prev := src()
+	cur := src()
+	runs := 1
+	up := cur > prev
+	for i := 2; i < n; i++ {
+		prev, cur = cur, src()
+		if (cur > prev) != up {
+			runs++
+			up = !up
+		}
+	}
+	fn := float64(n)
+	z := (float64(runs) - (2*fn-1)/3) / math.Sqrt((16*fn-29)/90)
+	return z, normalPValue(z)
+}
+
+// PermutationTest - тест перестановок: 5! вариантов упорядочения
+// неперекрывающихся групп из 5 чисел равновероятны
+func PermutationTest(src UniformSource, n int) (float64, float64) {
+	const t = 5
+	counts := make([]float64, 120)
+	var u [t]float64
+	for i := 0; i < n/t; i++ {
+		for j := range u {
+			u[j] = src()
+		}
+		// Номер перестановки по алгоритму P Кнута (факториальная система)
+		idx := 0
+		for r := t; r > 1; r-- {
+			s := 0
+			for j := 1; j < r; j++ {
+				if u[j] > u[s] {
+					s = j
+				}
+			}
+			idx = idx*r + s
+			u[s], u[r-1] = u[r-1], u[s]
+		}
+		counts[idx]++
+	}
+	return chiSquare(counts, UniformProbs(120))
+}
+
+// MaxOfTTest - тест максимума: для групп из t чисел величина max^t
+// равномерно распределена на [0, 1], что проверяется критерием
+// Колмогорова - Смирнова
+func MaxOfTTest(src UniformSource, n int) (float64, float64) {
+	const t = 8
+	values := make([]float64, n/t)
+	for i := range values {
+		m := 0.0
+		for j := 0; j < t; j++ {
+			m = math.Max(m, src())
+		}
+		values[i] = math.Pow(m, t)
+	}
+	res := stats.KSTest(values, func(x float64) float64 { return math.Min(math.Max(x, 0), 1) }, 0)
+	p := res.ExactP
+	if math.IsNaN(p) {
+		p = res.AsymptoticP
+	}
+	return res.D, p
+}
+
+// BirthdaySpacingsTest - тест дней рождения Марсальи: m = 512 дней
+// рождения в году из 2^24 дней; количество совпадающих расстояний между
+// соседними днями рождения имеет распределение Пуассона с λ = m^3/(4*2^24) = 2
+func BirthdaySpacingsTest(src UniformSource, n int) (float64, float64) {
+	const m, days, lambda, maxCount = 512, 1 << 24, 2.0, 6
+	counts := make([]float64, maxCount+1)
+	birthdays := make([]int, m)
+	spacings := make([]int, m)
+	for rep := 0; rep < n/m; rep++ {
+		for i := range birthdays {
+			birthdays[i] = digit(src(), days)
+		}
+		slices.Sort(birthdays)
+		spacings[0] = birthdays[0]
+		for i := 1; i < m; i++ {
+			spacings[i] = birthdays[i] - birthdays[i-1]
+		}
+		slices.Sort(spacings)
+		dup := 0
+		for i := 1; i < m; i++ {
+			if spacings[i] == spacings[i-1] {
+				dup++
+			}
+		}
+		counts[min(dup, maxCount)]++
+	}
+	probs := make([]float64, maxCount+1)
+	tail := 1.0
+	for k := 0; k < maxCount; k++ {
+		lg, _ := math.Lgamma(float64(k + 1))
+		probs[k] = math.Exp(-lambda + float64(k)*math.Log(lambda) - lg)
+		tail -= probs[k]
+	}
+	probs[maxCount] = tail
+	return chiSquare(counts, probs)
+}
+
+// AutocorrelationTest - сериальная корреляция со сдвигом lag: при
+// независимых числах z = r*sqrt(n-lag) асимптотически нормальна N(0, 1)
+func AutocorrelationTest(src UniformSource, n, lag int) (float64, float64) {
+	u := make([]float64, n)
+	for i := range u {
+		u[i] = src() - 0.5
+	}
+	var sum float64
+	for i := 0; i+lag < n; i++ {
+		sum += u[i] * u[i+lag]
+	}
+	pairs := float64(n - lag)
+	r := sum / pairs * 12
+	z := r * math.Sqrt(pairs)
+	return z, normalPValue(z)
+}
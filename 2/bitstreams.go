@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// BitStats - характеристики последовательности значений одного бита
+// выходных чисел генератора
+type BitStats struct {
+	Bit     int     // Номер бита (0 - младший)
+	Period  int     // Наименьший период на выборке (0 - не обнаружен)
+	Ones    float64 // Доля единиц
+	FreqP   float64 // p-значение частотного теста
+	MaxCorr float64 // Наибольшая по модулю автокорреляция
+	CorrLag int     // Сдвиг, на котором она достигается
+	CorrP   float64 // p-значение автокорреляции с поправкой Бонферрони на число сдвигов
+	Safe    bool    // Период не обнаружен и оба теста пройдены на уровне BitTestLevel
+}
+
+// BitTestLevel - уровень значимости одного теста, при котором вероятность
+// ложно забраковать хотя бы один из width бит по двум тестам не превышает
+// alpha (поправка Бонферрони на width*2 проверок)
+func BitTestLevel(alpha float64, width int) float64 {
+	return alpha / float64(2*width)
+}
+
+// AnalyzeBits - анализ каждого из width младших бит n выходных чисел next:
+// период последовательности бита, доля единиц и автокорреляция на
+// сдвигах 1..maxLag. У ЛКГ с модулем 2^e бит k имеет период не больше
+// 2^(k+1), поэтому младшие биты непригодны для использования.
+// alpha - уровень значимости для всего семейства тестов всех бит.
+func AnalyzeBits(next func() uint64, width, n, maxLag int, alpha float64) []BitStats {
+	words := make([]uint64, n)
+	for i := range words {
+		words[i] = next()
+	}
+
+	level := BitTestLevel(alpha, width)
+	stats := make([]BitStats, width)
+	s := make([]uint8, n)
+	for bit := range stats {
+		for i, w := range words {
+			s[i] = uint8(w >> bit & 1)
+		}
+		st := BitStats{Bit: bit, Period: bitPeriod(s), FreqP: FrequencyTest(s)}
+
+		ones := 0
+		for _, b := range s {
+			ones += int(b)
+		}
+		st.Ones = float64(ones) / float64(n)
+
+		// Автокорреляция последовательности ±1
+		minP := 1.0
+		for lag := 1; lag <= maxLag; lag++ {
+			sum := 0
+			for i := 0; i+lag < n; i++ {
+				sum += (2*int(s[i]) - 1) * (2*int(s[i+lag]) - 1)
+			}
+			pairs := float64(n - lag)
+			r := float64(sum) / pairs
+			if math.Abs(r) > math.Abs(st.MaxCorr) {
+				st.MaxCorr, st.CorrLag = r, lag
+			}
+			minP = math.Min(minP, normalPValue(r*math.Sqrt(pairs)))
+		}
+		st.CorrP = math.Min(minP*float64(maxLag), 1)
+		st.Safe = st.Period == 0 && st.FreqP >= level && st.CorrP >= level
+		stats[bit] = st
+	}
+	return stats
+}
+
+// bitPeriod - наименьший период последовательности s по префикс-функции
+// Кнута - Морриса - Пратта: p = n - π(n-1). Период считается обнаруженным,
+// если на выборке он повторяется хотя бы дважды (p <= n/2).
+func bitPeriod(s []uint8) int {
+	n := len(s)
+	if n == 0 {
+		return 0
+	}
+	pi := make([]int, n)
+	for i := 1; i < n; i++ {
+		k := pi[i-1]
+		for k > 0 && s[i] != s[k] {
+			k = pi[k-1]
+		}
+		if s[i] == s[k] {
+			k++
+		}
+		pi[i] = k
+	}
+	if p := n - pi[n-1]; p <= n/2 {
+		return p
+	}
+	return 0
+}
+
+// PrintBitStats - таблица характеристик бит
+func PrintBitStats(name string, stats []BitStats) {
+	fmt.Printf("Анализ отдельных бит генератора %s:\n", name)
+	fmt.Printf("%4s %10s %8s %10s %10s %6s %10s %8s\n", "Бит", "Период", "Доля 1", "p (част.)", "max |r|", "Сдвиг", "p (корр.)", "Годен")
+	for _, st := range stats {
+		period := "-"
+		if st.Period > 0 {
+			period = fmt.Sprint(st.Period)
+		}
+		safe := "нет"
+		if st.Safe {
+			safe = "да"
+		}
+		fmt.Printf("%4d %10s %8.4f %10.4f %10.4f %6d %10.4f %8s\n",
+			st.Bit, period, st.Ones, st.FreqP, math.Abs(st.MaxCorr), st.CorrLag, st.CorrP, safe)
+	}
+}
+
+// bitGrid - сетка оценок для тепловой карты: столбцы - тесты, строки - биты.
+// Оценки лежат в [0, 1], 1 - хороший результат.
+type bitGrid struct {
+	stats []BitStats
+	n     int
+	level float64
+}
+
+func (g bitGrid) Dims() (c, r int) { return 3, len(g.stats) }
+func (g bitGrid) X(c int) float64  { return float64(c) }
+func (g bitGrid) Y(r int) float64  { return float64(g.stats[r].Bit) }
+func (g bitGrid) Min() float64     { return 0 }
+func (g bitGrid) Max() float64     { return 1 }
+
+func (g bitGrid) Z(c, r int) float64 {
+	st := g.stats[r]
+	switch c {
+	case 0:
+		// Период относительно объема выборки в логарифмической шкале
+		if st.Period == 0 {
+			return 1
+		}
+		return math.Log2(float64(st.Period)) / math.Log2(float64(g.n))
+	case 1:
+		return math.Min(st.FreqP/g.level, 1)
+	default:
+		return math.Min(st.CorrP/g.level, 1)
+	}
+}
+
+// SaveBitHeatmap - тепловая карта "номер бита x тест": период (log2 периода,
+// деленный на log2 n) и p-значения частотного теста и автокорреляции,
+// деленные на уровень одного теста BitTestLevel (все p не ниже уровня
+// показываются одинаково).
+// Темные клетки - плохие результаты, светлые - хорошие.
+func SaveBitHeatmap(stats []BitStats, n int, alpha float64, title, filename string) error {
+	p := plot.New()
+	p.Title.Text = title
+	p.X.Label.Text = "Тест"
+	p.Y.Label.Text = "Номер бита"
+
+	hm := plotter.NewHeatMap(bitGrid{stats: stats, n: n, level: BitTestLevel(alpha, len(stats))}, palette.Heat(16, 1))
+	p.Add(hm)
+
+	p.X.Tick.Marker = plot.ConstantTicks([]plot.Tick{
+		{Value: 0, Label: "Период"}, {Value: 1, Label: "Частота"}, {Value: 2, Label: "Автокорреляция"},
+	})
+	var ticks []plot.Tick
+	for _, st := range stats {
+		label := ""
+		if st.Bit%4 == 0 {
+			label = fmt.Sprint(st.Bit)
+		}
+		ticks = append(ticks, plot.Tick{Value: float64(st.Bit), Label: label})
+	}
+	p.Y.Tick.Marker = plot.ConstantTicks(ticks)
+
+	return p.Save(6*vg.Inch, 10*vg.Inch, filename)
+}
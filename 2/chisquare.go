@@ -0,0 +1,202 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"labs/stats"
+)
+
+// Минимальное ожидаемое количество попаданий в интервал, при котором
+// распределение статистики Пирсона близко к хи-квадрат
+const chiSquareMinExpected = 5
+
+// ChiSquareResult - результат проверки гипотезы критерием Пирсона
+type ChiSquareResult struct {
+	Statistic float64 // Значение статистики χ²
+	DF        int     // Число степеней свободы
+	PValue    float64 // Вероятность получить χ² не меньше наблюдаемого при верной гипотезе
+	Alpha     float64 // Уровень значимости
+	Reject    bool    // Гипотеза отвергается (PValue < Alpha)
+	Cells     int     // Количество интервалов после объединения
+	Merged    int     // Количество исходных интервалов, объединенных с соседними
+}
+
+// String - краткий отчет о результате проверки
+func (r ChiSquareResult) String() string {
+	verdict := "гипотеза принимается"
+	if r.Reject {
+		verdict = "гипотеза отвергается"
+	}
+	return fmt.Sprintf("χ² = %.4f, степеней свободы %d, p = %.4f: %s на уровне значимости %g",
+		r.Statistic, r.DF, r.PValue, verdict, r.Alpha)
+}
+
+// ChiSquareTest - критерий согласия Пирсона. observed - наблюдаемые
+// количества попаданий в интервалы, probs - теоретические вероятности
+// попадания в те же интервалы (сумма равна 1), params - количество
+// параметров распределения, оцененных по той же выборке.
+// Соседние интервалы с ожидаемым количеством меньше 5 объединяются.
+func ChiSquareTest(observed, probs []float64, alpha float64, params int) (ChiSquareResult, error) {
+	if len(observed) != len(probs) {
+		return ChiSquareResult{}, errors.New("количество интервалов и вероятностей не совпадает")
+	}
+	var n, total float64
+	for i := range observed {
+		if observed[i] < 0 || probs[i] < 0 {
+			return ChiSquareResult{}, errors.New("количества и вероятности должны быть неотрицательными")
+		}
+		n += observed[i]
+		total += probs[i]
+	}
+	if math.Abs(total-1) > 1e-6 {
+		return ChiSquareResult{}, fmt.Errorf("сумма вероятностей равна %g, а не 1", total)
+	}
+	if n == 0 {
+		return ChiSquareResult{}, errors.New("пустая выборка")
+	}
+
+	// Объединение интервалов слева направо, пока ожидаемое количество
+	// не достигнет порога; остаток присоединяется к последней группе
+	var obs, exp []float64
+	var accObs, accExp float64
+	for i := range observed {
+		accObs += observed[i]
+		accExp += n * probs[i]
+		if accExp >= chiSquareMinExpected {
+			obs = append(obs, accObs)
+			exp = append(exp, accExp)
+			accObs, accExp = 0, 0
+		}
+	}
+	if accExp > 0 || accObs > 0 {
+		if len(obs) == 0 {
+			obs = append(obs, 0)
+			exp = append(exp, 0)
+		}
+		obs[len(obs)-1] += accObs
+		exp[len(exp)-1] += accExp
+	}
+
+	res := ChiSquareResult{Alpha: alpha, Cells: len(obs), Merged: len(observed) - len(obs)}
+	for i := range obs {
+		if exp[i] == 0 {
+			if obs[i] > 0 {
+				res.Statistic = math.Inf(1)
+			}
+			continue
+		}
+		d := obs[i] - exp[i]
+		res.Statistic += d * d / exp[i]
+	}
+
+	res.DF = len(obs) - 1 - params
+	if res.DF < 1 {
+		return res, fmt.Errorf("недостаточно интервалов: %d после объединения", len(obs))
+	}
+	res.PValue = GammaQ(float64(res.DF)/2, res.Statistic/2)
+	res.Reject = res.PValue < alpha
+	return res, nil
+}
+
+// BinCounts - количество значений data в каждом из k равных интервалов
+// на [a, b]; значения вне промежутка не учитываются
+func BinCounts(data []float64, a, b float64, k int) []float64 {
+	return stats.NewUniformHistogram(k, a, b).FillAll(data).Counts()
+}
+
+// UniformProbs - вероятности попадания в k равных интервалов
+// для равномерного распределения
+func UniformProbs(k int) []float64 {
+	probs := make([]float64, k)
+	for i := range probs {
+		probs[i] = 1 / float64(k)
+	}
+	return probs
+}
+
+// IntervalProbs - вероятности попадания в k равных интервалов на [a, b]
+// для распределения с функцией распределения cdf; вероятности хвостов
+// за пределами [a, b] добавляются к крайним интервалам
+func IntervalProbs(cdf func(float64) float64, a, b float64, k int) []float64 {
+	probs := make([]float64, k)
+	width := (b - a) / float64(k)
+	for i := range probs {
+		lo, hi := cdf(a+float64(i)*width), cdf(a+float64(i+1)*width)
+		if i == 0 {
+			lo = 0
+		}
+		if i == k-1 {
+			hi = 1
+		}
+		probs[i] = hi - lo
+	}
+	return probs
+}
+
+// GammaQ - регуляризованная верхняя неполная гамма-функция
+// Q(a, x) = Γ(a, x)/Γ(a): ряд при x < a+1, иначе цепная дробь
+func GammaQ(a, x float64) float64 {
+	switch {
+	case x <= 0:
+		return 1
+	case math.IsInf(x, 1):
+		return 0
+	case x < a+1:
+		return 1 - gammaSeries(a, x)
+	default:
+		return gammaContinuedFraction(a, x)
+	}
+}
+
+// GammaP - регуляризованная нижняя неполная гамма-функция P(a, x) = 1 - Q(a, x)
+func GammaP(a, x float64) float64 {
+	return 1 - GammaQ(a, x)
+}
+
+// gammaSeries - P(a, x) разложением в ряд
+func gammaSeries(a, x float64) float64 {
+	lg, _ := math.Lgamma(a)
+	ap := a
+	sum := 1 / a
+	del := sum
+	for n := 0; n < 1000; n++ {
+		ap++
+		del *= x / ap
+		sum += del
+		if math.Abs(del) < math.Abs(sum)*1e-15 {
+			break
+		}
+	}
+	return sum * math.Exp(-x+a*math.Log(x)-lg)
+}
+
+// gammaContinuedFraction - Q(a, x) цепной дробью (модифицированный метод Ленца)
+func gammaContinuedFraction(a, x float64) float64 {
+	const tiny = 1e-300
+	lg, _ := math.Lgamma(a)
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for i := 1; i < 1000; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < 1e-15 {
+			break
+		}
+	}
+	return math.Exp(-x+a*math.Log(x)-lg) * h
+}
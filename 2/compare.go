@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"labs/stats"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// GeneratorFactory - генератор, участвующий в сравнении. New возвращает
+// источник, начинающий последовательность заново, поэтому выборки разных
+// размеров - начальные отрезки одной последовательности.
+type GeneratorFactory struct {
+	Name string
+	New  func() UniformSource
+}
+
+// ComparisonConfig - параметры сравнения генераторов
+type ComparisonConfig struct {
+	A, B  float64 // Промежуток, на который отображаются числа из [0, 1)
+	Bins  int     // Количество интервалов гистограммы
+	Alpha float64 // Уровень значимости критериев согласия
+}
+
+// ComparisonRow - метрики выборки объема N одного генератора
+type ComparisonRow struct {
+	Name       string
+	N          int
+	Min, Max   float64
+	Mean       float64          // Выборочное среднее
+	MeanErr    float64          // Относительная погрешность среднего, %
+	StdErr     float64          // Стандартная ошибка среднего
+	Var        float64          // Исправленная выборочная дисперсия
+	VarErr     float64          // Относительная погрешность дисперсии, %
+	Repeat     []int64          // Первый повтор значения по RANDPeriod: [j-i, i, j] (-1 - повторов нет)
+	ChiSquare  ChiSquareResult  // Критерий Пирсона
+	KS         stats.KSResult   // Критерий Колмогорова - Смирнова
+	NsPerValue float64          // Время получения одного числа, нс
+	Hist       *stats.Histogram // Гистограмма выборки
+	Values     []float64        // Выборка, отображенная на [A, B]
+}
+
+// CompareGenerators - одни и те же метрики для каждого генератора и
+// каждого объема выборки: минимум и максимум, среднее и дисперсия с
+// погрешностями относительно теоретических, первый повтор значения,
+// гистограмма, критерии Пирсона и Колмогорова - Смирнова, время генерации
+func CompareGenerators(gens []GeneratorFactory, sizes []int, cfg ComparisonConfig) []ComparisonRow {
+	a, b := cfg.A, cfg.B
+	mean, variance := (a+b)/2, (b-a)*(b-a)/12
+	cdf := func(x float64) float64 { return math.Min(math.Max((x-a)/(b-a), 0), 1) }
+
+	var rows []ComparisonRow
+	for _, g := range gens {
+		for _, n := range sizes {
+			src := g.New()
+			values := make([]float64, n)
+			start := time.Now()
+			for i := range values {
+				values[i] = src()
+			}
+			elapsed := time.Since(start)
+			for i := range values {
+				values[i] = a + (b-a)*values[i]
+			}
+
+			row := ComparisonRow{
+				Name:       g.Name,
+				N:          n,
+				Min:        slices.Min(values),
+				Max:        slices.Max(values),
+				Repeat:     RANDPeriod(values),
+				NsPerValue: float64(elapsed.Nanoseconds()) / float64(n),
+				Hist:       stats.NewUniformHistogram(cfg.Bins, a, b).FillAll(values),
+				Values:     values,
+			}
+			var sum, sumSq float64
+			for _, x := range values {
+				sum += x
+				sumSq += x * x
+			}
+			fn := float64(n)
+			row.Mean = sum / fn
+			row.Var = (sumSq/fn - row.Mean*row.Mean) * fn / (fn - 1)
+			row.StdErr = math.Sqrt(row.Var / fn)
+			row.MeanErr = math.Abs((mean-row.Mean)/mean) * 100
+			row.VarErr = math.Abs((variance-row.Var)/variance) * 100
+			row.ChiSquare, _ = ChiSquareTest(row.Hist.Counts(), UniformProbs(cfg.Bins), cfg.Alpha, 0)
+			row.KS = stats.KSTest(values, cdf, cfg.Alpha)
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// PrintComparison - сводная таблица сравнения генераторов
+func PrintComparison(rows []ComparisonRow) {
+	fmt.Printf("%-14s %7s %7s %7s %16s %7s %8s %7s %7s %9s %9s %8s\n",
+		"Генератор", "N", "Min", "Max", "M ± ст. ошибка", "δM, %", "D", "δD, %", "Повтор", "p (χ²)", "p (K-S)", "нс/число")
+	for _, r := range rows {
+		ksP := r.KS.ExactP
+		if math.IsNaN(ksP) {
+			ksP = r.KS.AsymptoticP
+		}
+		repeat := "нет"
+		if r.Repeat[0] >= 0 {
+			repeat = fmt.Sprint(r.Repeat[0])
+		}
+		fmt.Printf("%-14s %7d %7.4f %7.4f %8.4f ± %5.4f %7.3f %8.4f %7.3f %7s %9.4f %9.4f %8.1f\n",
+			r.Name, r.N, r.Min, r.Max, r.Mean, r.StdErr, r.MeanErr, r.Var, r.VarErr, repeat, r.ChiSquare.PValue, ksP, r.NsPerValue)
+	}
+}
+
+// SaveComparisonHistograms - наложенные гистограммы плотности всех
+// генераторов для выборок объема n и теоретическая плотность 1/(b-a)
+func SaveComparisonHistograms(rows []ComparisonRow, n int, cfg ComparisonConfig, filename string) error {
+	palette := []color{
+		{255, 0, 0}, {0, 0, 255}, {0, 160, 0}, {255, 140, 0},
+		{128, 0, 128}, {0, 160, 160}, {120, 120, 120}, {0, 0, 0},
+	}
+
+	p := plot.New()
+	p.Title.Text = fmt.Sprintf("Гистограммы относительных частот, N=%d", n)
+	p.X.Label.Text = "x"
+	p.Y.Label.Text = "Плотность"
+	p.Legend.Top = true
+
+	k := 0
+	for _, r := range rows {
+		if r.N != n {
+			continue
+		}
+		// Ступенчатая линия по границам интервалов
+		dens := r.Hist.Densities()
+		var pts plotter.XYs
+		for i, d := range dens {
+			pts = append(pts, plotter.XY{X: r.Hist.Edges[i], Y: d}, plotter.XY{X: r.Hist.Edges[i+1], Y: d})
+		}
+		line, err := plotter.NewLine(pts)
+		if err != nil {
+			return err
+		}
+		line.Color = palette[k%len(palette)]
+		line.Width = vg.Points(1.5)
+		p.Add(line)
+		p.Legend.Add(r.Name, line)
+		k++
+	}
+
+	theory, err := plotter.NewLine(plotter.XYs{{X: cfg.A, Y: 1 / (cfg.B - cfg.A)}, {X: cfg.B, Y: 1 / (cfg.B - cfg.A)}})
+	if err != nil {
+		return err
+	}
+	theory.Color = color{0, 0, 0}
+	theory.Width = vg.Points(1)
+	theory.Dashes = []vg.Length{vg.Points(4), vg.Points(4)}
+	p.Add(theory)
+	p.Legend.Add("Теоретическая", theory)
+
+	return p.Save(10*vg.Inch, 6*vg.Inch, filename)
+}
@@ -0,0 +1,68 @@
+package main
+
+import "fmt"
+
+// CycleInfo - структура последовательности x_{i+1} = f(x_i):
+// x_0, ..., x_{Mu-1} - апериодический отрезок (предпериод),
+// далее значения повторяются с периодом Lambda.
+// Lambda == 0 означает период 2^64 (по аналогии с модулем LCG.M == 0).
+type CycleInfo struct {
+	Mu     uint64 // Длина предпериода
+	Lambda uint64 // Длина периода
+}
+
+// FindCycle - поиск предпериода и периода алгоритмом Брента за
+// O(Mu+Lambda) вычислений f и O(1) памяти. Сравниваются целые состояния,
+// а не нормированные значения, поэтому совпадение означает настоящий
+// повтор последовательности. limit ограничивает количество шагов
+// на каждом этапе (0 - без ограничения).
+func FindCycle(f func(uint64) uint64, x0 uint64, limit uint64) (CycleInfo, error) {
+	// Этап 1: период. "Черепаха" переносится в позицию "зайца" на шагах,
+	// равных степеням двойки, пока заяц не догонит ее.
+	// При периоде 2^64 счетчики power и lambda переполняются
+	// одновременно, и результат Lambda == 0 соответствует 2^64.
+	power, lambda := uint64(1), uint64(1)
+	tortoise, hare := x0, f(x0)
+	var steps uint64
+	for tortoise != hare {
+		if power == lambda {
+			tortoise = hare
+			power <<= 1
+			lambda = 0
+		}
+		hare = f(hare)
+		lambda++
+		steps++
+		if limit > 0 && steps >= limit {
+			return CycleInfo{}, fmt.Errorf("период не найден за %d шагов", limit)
+		}
+	}
+
+	// Период 2^64 охватывает все 64-битные состояния, предпериода нет
+	if lambda == 0 {
+		return CycleInfo{}, nil
+	}
+
+	// Этап 2: предпериод. Заяц опережает черепаху на Lambda шагов,
+	// первое совпадение происходит в начале цикла.
+	tortoise, hare = x0, x0
+	for i := uint64(0); i < lambda; i++ {
+		hare = f(hare)
+	}
+	var mu uint64
+	for tortoise != hare {
+		tortoise = f(tortoise)
+		hare = f(hare)
+		mu++
+		if limit > 0 && mu >= limit {
+			return CycleInfo{}, fmt.Errorf("предпериод не найден за %d шагов", limit)
+		}
+	}
+	return CycleInfo{Mu: mu, Lambda: lambda}, nil
+}
+
+// Cycle - предпериод и период генератора, начиная с текущего состояния;
+// состояние генератора не изменяется
+func (g *LCG) Cycle(limit uint64) (CycleInfo, error) {
+	return FindCycle(g.step, g.X, limit)
+}
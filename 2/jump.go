@@ -0,0 +1,223 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// mul - произведение по модулю генератора (M == 0 - по модулю 2^64)
+func (g *LCG) mul(x, y uint64) uint64 {
+	if g.M == 0 {
+		return x * y
+	}
+	return mulMod(x, y, g.M)
+}
+
+// add - сумма по модулю генератора
+func (g *LCG) add(x, y uint64) uint64 {
+	if g.M == 0 {
+		return x + y
+	}
+	s := x + y
+	if s < x || s >= g.M {
+		s -= g.M
+	}
+	return s
+}
+
+// JumpMap - коэффициенты отображения x -> A*x + C, переводящего x_n в
+// x_{n+k}. Отображение x -> a*x + c возводится в степень k повторным
+// возведением в квадрат: (a, c)∘(a, c) = (a^2, a*c + c), всего O(log k)
+// умножений.
+func (g *LCG) JumpMap(k uint64) (A, C uint64) {
+	A, C = 1, 0
+	a, c := g.A, g.C
+	for ; k > 0; k >>= 1 {
+		if k&1 == 1 {
+			A, C = g.mul(a, A), g.add(g.mul(a, C), c)
+		}
+		a, c = g.mul(a, a), g.add(g.mul(a, c), c)
+	}
+	return A, C
+}
+
+// Jump - пропуск k чисел: состояние x_n заменяется на x_{n+k}
+func (g *LCG) Jump(k uint64) {
+	A, C := g.JumpMap(k)
+	g.X = g.add(g.mul(A, g.X), C)
+}
+
+// Period - период последовательности от текущего состояния (0 - 2^64).
+// Известен при выполнении условий Халла - Добелла (период равен m) и для
+// мультипликативного генератора с начальным значением, взаимно простым
+// с m (период равен порядку a по модулю m).
+func (g *LCG) Period() (uint64, error) {
+	if g.Check.FullPeriod {
+		return g.M, nil
+	}
+	if g.C != 0 {
+		return 0, errors.New("условия Халла - Добелла не выполнены, период зависит от начального значения")
+	}
+	coprime := g.X%2 == 1 && g.A%2 == 1
+	if g.M != 0 {
+		coprime = gcd(g.X, g.M) == 1 && gcd(g.A, g.M) == 1
+	}
+	if !coprime {
+		return 0, errors.New("множитель и начальное значение мультипликативного генератора должны быть взаимно просты с m")
+	}
+
+	// Порядок a делит функцию Кармайкла λ(m); лишние простые множители
+	// λ(m) удаляются, пока a^(order/q) ≡ 1
+	order := g.carmichael()
+	if order == 1 {
+		return 1, nil
+	}
+	for _, q := range PrimeFactors(order) {
+		for order%q == 0 && g.pow(g.A, order/q) == 1 {
+			order /= q
+		}
+	}
+	return order, nil
+}
+
+// pow - возведение в степень по модулю генератора
+func (g *LCG) pow(x, e uint64) uint64 {
+	result := uint64(1)
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result = g.mul(result, x)
+		}
+		x = g.mul(x, x)
+	}
+	return result
+}
+
+// carmichael - функция Кармайкла λ(m): наименьшее общее кратное
+// λ(p^k) = p^(k-1)(p-1) и λ(2^k) = 2^(k-2) при k >= 3
+func (g *LCG) carmichael() uint64 {
+	if g.M == 0 {
+		return 1 << 62
+	}
+	lambda := uint64(1)
+	for _, p := range PrimeFactors(g.M) {
+		pk, k := uint64(1), 0
+		for m := g.M; m%p == 0; m /= p {
+			pk *= p
+			k++
+		}
+		l := pk / p * (p - 1)
+		if p == 2 && k >= 3 {
+			l /= 2
+		}
+		lambda = lambda / gcd(lambda, l) * l
+	}
+	return lambda
+}
+
+// Параметры проверки потоков: сдвиги, на которых сравниваются потоки, и
+// наименьший допустимый показатель качества двумерной решетки
+const (
+	SubstreamMaxLag   = 8
+	substreamMinMerit = 0.05
+)
+
+// Substreams - разбиение периода генератора на count непересекающихся
+// блоков равной длины. Поток i начинается с x_{i*L}, где L - длина блока;
+// пока из каждого потока взято не больше L чисел, последовательности
+// потоков не пересекаются. Непересекающиеся потоки все же могут быть
+// зависимы: поток k получается из потока 0 отображением
+// x -> A^k x + C_k (A - множитель скачка на L), и при A = 1 потоки
+// отличаются сдвигом, при A = m-1 - отражением. Поэтому разбиение
+// отклоняется, если какая-либо пара потоков на сдвигах до SubstreamMaxLag
+// лежит на решетке с малым показателем качества (см. checkStreamMaps).
+func (g *LCG) Substreams(count int) ([]*LCG, uint64, error) {
+	if count < 1 {
+		return nil, 0, fmt.Errorf("некорректное количество потоков: %d", count)
+	}
+	period, err := g.Period()
+	if err != nil {
+		return nil, 0, err
+	}
+	blockLen := period / uint64(count)
+	if blockLen == 0 {
+		return nil, 0, fmt.Errorf("период %d меньше количества потоков %d", period, count)
+	}
+	A, C := g.JumpMap(blockLen)
+	if err := g.checkStreamMaps(A, count); err != nil {
+		return nil, 0, err
+	}
+
+	streams := make([]*LCG, count)
+	x := g.X
+	for i := range streams {
+		s := *g
+		s.X = x
+		streams[i] = &s
+		x = g.add(g.mul(A, x), C)
+	}
+	return streams, blockLen, nil
+}
+
+// checkStreamMaps - проверка связи между потоками. Поток i+k на шаге n
+// и поток i на шаге n+l связаны как x' = A^k a^(-l) x + c (mod m), поэтому
+// пары их значений лежат на решетке двумерного спектрального теста с
+// множителем A^k a^(-l). Для обратного множителя решетка та же с
+// переставленными координатами, так что достаточно k > 0. Множитель a
+// обратим: при полном периоде и у мультипликативного генератора с
+// известным периодом он взаимно прост с m.
+func (g *LCG) checkStreamMaps(A uint64, count int) error {
+	aInv := g.pow(g.A, g.carmichael()-1)
+	B := uint64(1)
+	for k := 1; k < count; k++ {
+		B = g.mul(B, A)
+		mult := g.mul(B, g.pow(g.A, SubstreamMaxLag+1))
+		for l := -SubstreamMaxLag; l <= SubstreamMaxLag; l++ {
+			mult = g.mul(mult, aInv)
+			if mult == 1 {
+				return fmt.Errorf("поток %d повторяет поток 0 со сдвигом %d", k, l)
+			}
+			result, err := SpectralTest(mult, g.M, 2)
+			if err != nil {
+				return err
+			}
+			if merit := result[0].Merit; merit < substreamMinMerit {
+				return fmt.Errorf("поток %d и поток 0 со сдвигом %d связаны множителем %d (показатель решетки %.2g < %g)",
+					k, l, mult, merit, substreamMinMerit)
+			}
+		}
+	}
+	return nil
+}
+
+// correlation - выборочный коэффициент корреляции Пирсона
+func correlation(u, v []float64) float64 {
+	n := float64(len(u))
+	var su, sv, suu, svv, suv float64
+	for i := range u {
+		su += u[i]
+		sv += v[i]
+		suu += u[i] * u[i]
+		svv += v[i] * v[i]
+		suv += u[i] * v[i]
+	}
+	cov := suv - su*sv/n
+	return cov / math.Sqrt((suu-su*su/n)*(svv-sv*sv/n))
+}
+
+// MaxCrossCorrelation - наибольшая по модулю корреляция u_n и v_{n+l}
+// по сдвигам l = -maxLag..maxLag
+func MaxCrossCorrelation(u, v []float64, maxLag int) (r float64, lag int) {
+	for l := -maxLag; l <= maxLag; l++ {
+		x, y := u, v
+		if l >= 0 {
+			x, y = u[:len(u)-l], v[l:]
+		} else {
+			x, y = u[-l:], v[:len(v)+l]
+		}
+		if c := correlation(x, y); math.Abs(c) > math.Abs(r) {
+			r, lag = c, l
+		}
+	}
+	return r, lag
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strings"
+)
+
+// LCG - линейный конгруэнтный генератор x_{i+1} = (A*x_i + C) mod M.
+// M == 0 означает модуль 2^64. Произведение A*x вычисляется в 128-битной
+// арифметике, поэтому допустим любой модуль до 2^64 включительно.
+type LCG struct {
+	A, C, M uint64
+	X       uint64 // Текущее состояние
+
+	Check HullDobell // Результат проверки условий Халла - Добелла
+}
+
+// HullDobell - проверка условий теоремы Халла - Добелла, при выполнении
+// которых период генератора равен модулю M:
+//  1. C и M взаимно просты;
+//  2. A-1 делится на все простые делители M;
+//  3. если M делится на 4, то и A-1 делится на 4.
+type HullDobell struct {
+	CoprimeC      bool     // Условие 1
+	PrimeFactors  bool     // Условие 2
+	FourDivides   bool     // Условие 3
+	Factors       []uint64 // Простые делители M
+	FullPeriod    bool     // Выполнены все три условия
+	FailedReasons []string // Описание невыполненных условий
+}
+
+// NewLCG - генератор с параметрами a, c, m и начальным значением seed.
+// Возвращает ошибку при недопустимых параметрах; выполнение условий
+// полного периода записывается в поле Check.
+func NewLCG(a, c, m, seed uint64) (*LCG, error) {
+	if m == 1 {
+		return nil, errors.New("модуль должен быть больше 1")
+	}
+	if m != 0 && (a >= m || c >= m || seed >= m) {
+		return nil, fmt.Errorf("параметры a=%d, c=%d и x0=%d должны быть меньше модуля m=%d", a, c, seed, m)
+	}
+	if a == 0 {
+		return nil, errors.New("множитель a должен быть отличен от нуля")
+	}
+	g := &LCG{A: a, C: c, M: m, X: seed}
+	g.Check = checkHullDobell(a, c, m)
+	return g, nil
+}
+
+// checkHullDobell - проверка условий Халла - Добелла для a, c, m
+func checkHullDobell(a, c, m uint64) HullDobell {
+	var hd HullDobell
+
+	// Для M = 2^64 единственный простой делитель - 2, а a-1 и c
+	// рассматриваются как обычные 64-битные числа
+	if m == 0 {
+		hd.Factors = []uint64{2}
+		hd.CoprimeC = c%2 == 1
+		hd.PrimeFactors = (a-1)%2 == 0
+		hd.FourDivides = (a-1)%4 == 0
+	} else {
+		hd.Factors = PrimeFactors(m)
+		hd.CoprimeC = gcd(c, m) == 1
+		hd.PrimeFactors = true
+		for _, p := range hd.Factors {
+			if (a-1)%p != 0 {
+				hd.PrimeFactors = false
+			}
+		}
+		hd.FourDivides = m%4 != 0 || (a-1)%4 == 0
+	}
+
+	if !hd.CoprimeC {
+		hd.FailedReasons = append(hd.FailedReasons, "c и m не взаимно просты")
+	}
+	if !hd.PrimeFactors {
+		hd.FailedReasons = append(hd.FailedReasons, "a-1 делится не на все простые делители m")
+	}
+	if !hd.FourDivides {
+		hd.FailedReasons = append(hd.FailedReasons, "m делится на 4, а a-1 - нет")
+	}
+	hd.FullPeriod = hd.CoprimeC && hd.PrimeFactors && hd.FourDivides
+	return hd
+}
+
+// String - краткий отчет о выполнении условий полного периода
+func (hd HullDobell) String() string {
+	if hd.FullPeriod {
+		return fmt.Sprintf("условия Халла - Добелла выполнены, период равен модулю (простые делители m: %v)", hd.Factors)
+	}
+	return fmt.Sprintf("полный период не гарантирован: %s (простые делители m: %v)", strings.Join(hd.FailedReasons, "; "), hd.Factors)
+}
+
+// Seed - установка состояния генератора
+func (g *LCG) Seed(x uint64) {
+	g.X = x
+}
+
+// step - следующее состояние (a*x + c) mod m
+func (g *LCG) step(x uint64) uint64 {
+	hi, lo := bits.Mul64(g.A, x)
+	lo, carry := bits.Add64(lo, g.C, 0)
+	hi += carry
+	if g.M == 0 {
+		return lo
+	}
+	// Для модуля - степени двойки деление не нужно
+	if g.M&(g.M-1) == 0 {
+		return lo & (g.M - 1)
+	}
+	return bits.Rem64(hi, lo, g.M)
+}
+
+// Next - переход в следующее состояние, возвращает новое состояние
+func (g *LCG) Next() uint64 {
+	g.X = g.step(g.X)
+	return g.X
+}
+
+// Float64 - следующее число, нормированное на модуль: x/m из [0, 1)
+func (g *LCG) Float64() float64 {
+	x := g.Next()
+	if g.M == 0 {
+		return float64(x>>11) / (1 << 53)
+	}
+	u := float64(x) / float64(g.M)
+	// При m > 2^53 округление может дать ровно 1
+	if u >= 1 {
+		u = math.Nextafter(1, 0)
+	}
+	return u
+}
+
+// Floats - следующие n чисел из [0, 1)
+func (g *LCG) Floats(n int) []float64 {
+	res := make([]float64, n)
+	for i := range res {
+		res[i] = g.Float64()
+	}
+	return res
+}
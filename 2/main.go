@@ -1,49 +1,48 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
+	"labs/stats"
 	"math"
+	"math/big"
+	"math/bits"
 	"math/rand"
-	"slices"
+	"os"
 )
 
 // Задание 5:
 
 func GetFreqDistr(RParamsArr []float64, A, B float64, IntervalsCount int) []float64 {
-	var dY float64 = (B - A) / float64(IntervalsCount)
-	Freq := make([]float64, IntervalsCount)
-
-	var fN int
-	for i := 0; i < len(RParamsArr); i++ {
-		fN = int(math.Floor(RParamsArr[i] / dY))
-		Freq[fN]++
-	}
-
-	for i := 0; i < IntervalsCount; i++ {
-		Freq[i] /= float64(len(RParamsArr)) * dY
-	}
-
-	return Freq
+	return stats.NewUniformHistogram(IntervalsCount, A, B).FillAll(RParamsArr).Densities()
 }
 
 // Задание 4: функция RANDPeriod
 
 func RANDPeriod(X []float64) []int64 {
-	var n int64 = int64(len(X))
-
-	var element float64
-	for i := int64(0); i < n; i++ {
-		element = X[i]
-		for j := i + 1; j < n; j++ {
-			if element == X[j] {
-				return []int64{j - i, i, j}
-			}
+	// Для каждого значения запоминаются первое и второе вхождения;
+	// результат - значение с наименьшим индексом первого вхождения,
+	// которое встречается повторно
+	first := make(map[float64]int64, len(X))
+	second := make(map[float64]int64)
+	for j, element := range X {
+		if _, ok := first[element]; !ok {
+			first[element] = int64(j)
+		} else if _, ok := second[element]; !ok {
+			second[element] = int64(j)
+		}
+	}
+
+	result := []int64{-1, -1, -1}
+	for element, j := range second {
+		if i := first[element]; result[1] < 0 || i < result[1] {
+			result = []int64{j - i, i, j}
 		}
 	}
-	return []int64{-1, -1, -1}
+	return result
 }
 
 // Задание 1: функция RAND
@@ -54,503 +53,402 @@ func RAND(a, b, m, x_i int64) int64 {
 
 func main() {
 
+	// Вывод генератора в виде потока 32-битных слов для внешних наборов тестов:
+	// go run . -raw 0 | dieharder -a -g 200
+	// go run . -raw 0 | RNG_test stdin32
+	RawFlag := flag.Int("raw", -1, "вывести указанное количество 32-битных слов генератора в stdout (0 - без ограничения)")
+	LCGFlag := flag.String("lcg", "", "использовать генератор из каталога LCGPresets вместо параметров работы (minstd, glibc, msvc, nr, borland, java, randu, ...)")
+	flag.Parse()
+
 	// Задание 2: расчет последовательностей случайных чисел
 
 	var a, b, m int64 = 22695477, 1, 1 << 32
 	var x0 int64 = 1
 
-	var A, B float64 = 0, 10
-	var N int = 100
-
-	RNumsArr := make([]float64, N)
-	RNumsArr[0] = float64(RAND(a, b, m, x0))
-	for i := 1; i < N; i++ {
-		RNumsArr[i] = float64(RAND(a, b, m, int64(RNumsArr[i-1])))
-	}
-	for i := 0; i < N; i++ {
-		RNumsArr[i] = RNumsArr[i] / float64(m)
-	}
-
-	RParamsArr := make([]float64, N)
-	for i := 0; i < N; i++ {
-		RParamsArr[i] = A + (B-A)*RNumsArr[i]
-	}
-
-	fmt.Println("Максимальное значение при N=100:", slices.Max(RParamsArr))
-	fmt.Println("Минимальное значение при N=100:", slices.Min(RParamsArr), "\n")
-
-	N = 1000
-
-	RNumsArr = make([]float64, N)
-	RNumsArr[0] = float64(RAND(a, b, m, x0))
-	for i := 1; i < N; i++ {
-		RNumsArr[i] = float64(RAND(a, b, m, int64(RNumsArr[i-1])))
-	}
-	for i := 0; i < N; i++ {
-		RNumsArr[i] = RNumsArr[i] / float64(m)
-	}
-
-	RParamsArr_e3 := make([]float64, N)
-	for i := 0; i < N; i++ {
-		RParamsArr_e3[i] = A + (B-A)*RNumsArr[i]
-	}
-
-	fmt.Println("Максимальное значение при N=1000:", slices.Max(RParamsArr_e3))
-	fmt.Println("Минимальное значение при N=1000:", slices.Min(RParamsArr_e3), "\n")
-
-	N = 10000
-
-	RNumsArr = make([]float64, N)
-	RNumsArr[0] = float64(RAND(a, b, m, x0))
-	for i := 1; i < N; i++ {
-		RNumsArr[i] = float64(RAND(a, b, m, int64(RNumsArr[i-1])))
-	}
-	for i := 0; i < N; i++ {
-		RNumsArr[i] = RNumsArr[i] / float64(m)
-	}
-
-	RParamsArr_e4 := make([]float64, N)
-	for i := 0; i < N; i++ {
-		RParamsArr_e4[i] = A + (B-A)*RNumsArr[i]
+	// Генератор хранит параметры и состояние, период проверяется
+	// по условиям Халла - Добелла
+	Gen, err := NewLCG(uint64(a), uint64(b), uint64(m), uint64(x0))
+	if *LCGFlag != "" {
+		Gen, err = NewLCGByName(*LCGFlag, uint64(x0))
+	}
+	if err != nil {
+		fmt.Println("Ошибка параметров генератора:", err)
+		return
+	}
+	// Начальное состояние (у генераторов каталога seed может перемешиваться)
+	StartX := Gen.X
+	if *RawFlag >= 0 {
+		if err := WriteRaw(os.Stdout, Gen.Uint32, *RawFlag); err != nil {
+			fmt.Fprintln(os.Stderr, "Ошибка записи:", err)
+		}
+		return
 	}
+	fmt.Printf("Генератор %s: %s\n", Gen, Gen.Check)
+	fmt.Println()
 
-	fmt.Println("Максимальное значение при N=10000:", slices.Max(RParamsArr_e4))
-	fmt.Println("Минимальное значение при N=10000:", slices.Min(RParamsArr_e4), "\n")
-
-	N = 100000
-
-	RNumsArr = make([]float64, N)
-	RNumsArr[0] = float64(RAND(a, b, m, x0))
-	for i := 1; i < N; i++ {
-		RNumsArr[i] = float64(RAND(a, b, m, int64(RNumsArr[i-1])))
-	}
-	for i := 0; i < N; i++ {
-		RNumsArr[i] = RNumsArr[i] / float64(m)
-	}
+	var A, B float64 = 0, 10
 
-	RParamsArr_e5 := make([]float64, N)
-	for i := 0; i < N; i++ {
-		RParamsArr_e5[i] = A + (B-A)*RNumsArr[i]
+	// Количество интервалов гистограмм и уровень значимости критериев согласия
+	var K int = 10
+	const Alpha = 0.05
+
+	// Задания 2-6 выполняются по строкам сравнения генераторов (задание 7),
+	// относящимся к генератору работы: выборки объема N - начальные отрезки
+	// его последовательности, отображенные на [A;B]
+
+	Comparison := []GeneratorFactory{
+		{"ЛКГ работы", func() UniformSource {
+			g := *Gen
+			g.Seed(StartX)
+			return g.Float64
+		}},
+		{"math/rand", func() UniformSource { return rand.New(rand.NewSource(1)).Float64 }},
+		{"MT19937", func() UniformSource { return NewMT19937(5489).Float64 }},
+		{"PCG32", func() UniformSource { return NewPCG32(42, 54).Float64 }},
+		{"xoshiro256**", func() UniformSource { return NewXoshiro256StarStar(1).Float64 }},
+		{"MRG32k3a", func() UniformSource { return NewMRG32k3a(12345).Float64 }},
+		{"RANDU", func() UniformSource {
+			g, _ := NewLCGByName("randu", 1)
+			return g.Float64
+		}},
+	}
+	Sizes := []int{100, 1000, 10000, 100000}
+	CompareCfg := ComparisonConfig{A: A, B: B, Bins: K, Alpha: Alpha}
+	Rows := CompareGenerators(Comparison, Sizes, CompareCfg)
+	LabRows := Rows[:len(Sizes)]
+
+	// Задание 2
+
+	for _, Row := range LabRows {
+		fmt.Printf("Максимальное значение при N=%d: %v\n", Row.N, Row.Max)
+		fmt.Printf("Минимальное значение при N=%d: %v\n", Row.N, Row.Min)
+		fmt.Println()
 	}
 
-	fmt.Println("Максимальное значение при N=100000:", slices.Max(RParamsArr_e5))
-	fmt.Println("Минимальное значение при N=100000:", slices.Min(RParamsArr_e5), "\n")
-
 	// Задание 3
 
 	var M float64 = (A + B) / 2
 	fmt.Println("Теоретическое мат. ожидание:", M)
 	var D float64 = ((B - A) * (B - A)) / 12
-	fmt.Println("Теоретическая дисперсия:", D, "\n")
+	fmt.Println("Теоретическая дисперсия:", D)
+	fmt.Println()
 
-	N = 100
-
-	var M_e2 float64
-	for i := 0; i < N; i++ {
-		M_e2 += RParamsArr[i]
+	for _, Row := range LabRows {
+		fmt.Printf("Мат. ожидание при N=%d: %v\n", Row.N, Row.Mean)
+		fmt.Printf("Погрешность мат. ожидания при N=%d: %v\n", Row.N, Row.MeanErr)
+		fmt.Printf("Дисперсия при N=%d: %v\n", Row.N, Row.Var)
+		fmt.Printf("Погрешность дисперсии при N=%d: %v\n", Row.N, Row.VarErr)
+		fmt.Println()
 	}
-	M_e2 /= float64(N)
-	fmt.Println("Мат. ожидание при N=100:", M_e2)
-
-	var EpsM1 float64 = math.Abs((M-M_e2)/M) * 100
-	fmt.Println("Погрешность мат. ожидания при N=100:", EpsM1)
-
-	var D_e2 float64
-	for i := 0; i < N; i++ {
-		D_e2 += RParamsArr[i] * RParamsArr[i]
-	}
-	D_e2 /= float64(N)
-	D_e2 -= M_e2 * M_e2
-	D_e2 *= float64(N) / (float64(N) - 1)
-	fmt.Println("Дисперсия при N=100:", D_e2)
-
-	var EpsD1 float64 = math.Abs((D-D_e2)/D) * 100
-	fmt.Println("Погрешность дисперсии при N=100:", EpsD1, "\n")
-
-	N = 1000
-
-	var M_e3 float64
-	for i := 0; i < N; i++ {
-		M_e3 += RParamsArr_e3[i]
-	}
-	M_e3 /= float64(N)
-	fmt.Println("Мат. ожидание при N=1000:", M_e3)
-
-	var EpsM2 float64 = math.Abs((M-M_e3)/M) * 100
-	fmt.Println("Погрешность мат. ожидания при N=1000:", EpsM2)
-
-	var D_e3 float64
-	for i := 0; i < N; i++ {
-		D_e3 += RParamsArr_e3[i] * RParamsArr_e3[i]
-	}
-	D_e3 /= float64(N)
-	D_e3 -= M_e3 * M_e3
-	D_e3 *= float64(N) / (float64(N) - 1)
-	fmt.Println("Дисперсия при N=1000:", D_e3)
-
-	var EpsD2 float64 = math.Abs((D-D_e3)/D) * 100
-	fmt.Println("Погрешность дисперсии при N=1000:", EpsD2, "\n")
-
-	N = 10000
-
-	var M_e4 float64
-	for i := 0; i < N; i++ {
-		M_e4 += RParamsArr_e4[i]
-	}
-	M_e4 /= float64(N)
-	fmt.Println("Мат. ожидание при N=10000:", M_e4)
-
-	var EpsM3 float64 = math.Abs((M-M_e4)/M) * 100
-	fmt.Println("Погрешность мат. ожидания при N=10000:", EpsM3)
-
-	var D_e4 float64
-	for i := 0; i < N; i++ {
-		D_e4 += RParamsArr_e4[i] * RParamsArr_e4[i]
-	}
-	D_e4 /= float64(N)
-	D_e4 -= M_e4 * M_e4
-	D_e4 *= float64(N) / (float64(N) - 1)
-	fmt.Println("Дисперсия при N=10000:", D_e4)
-
-	var EpsD3 float64 = math.Abs((D-D_e4)/D) * 100
-	fmt.Println("Погрешность дисперсии при N=10000:", EpsD3, "\n")
-
-	N = 100000
-
-	var M_e5 float64
-	for i := 0; i < N; i++ {
-		M_e5 += RParamsArr_e5[i]
-	}
-	M_e5 /= float64(N)
-	fmt.Println("Мат. ожидание при N=100000:", M_e5)
-
-	var EpsM4 float64 = math.Abs((M-M_e5)/M) * 100
-	fmt.Println("Погрешность мат. ожидания при N=100000:", EpsM4)
-
-	var D_e5 float64
-	for i := 0; i < N; i++ {
-		D_e5 += RParamsArr_e5[i] * RParamsArr_e5[i]
-	}
-	D_e5 /= float64(N)
-	D_e5 -= M_e5 * M_e5
-	D_e5 *= float64(N) / (float64(N) - 1)
-	fmt.Println("Дисперсия при N=100000:", D_e5)
-
-	var EpsD4 float64 = math.Abs((D-D_e5)/D) * 100
-	fmt.Println("Погрешность дисперсии при N=100000:", EpsD4, "\n")
 
 	// Задание 4
 
-	var TEST_1 = RANDPeriod(RParamsArr)
-	fmt.Println("Результаты теста на периодичность последовательности при N=100:", TEST_1)
-	var TEST_2 = RANDPeriod(RParamsArr_e3)
-	fmt.Println("Результаты теста на периодичность последовательности при N=1000:", TEST_2)
-	var TEST_3 = RANDPeriod(RParamsArr_e4)
-	fmt.Println("Результаты теста на периодичность последовательности при N=10000:", TEST_3)
-	var TEST_4 = RANDPeriod(RParamsArr_e5)
-	fmt.Println("Результаты теста на периодичность последовательности при N=100000:", TEST_4, "\n")
+	for _, Row := range LabRows {
+		fmt.Printf("Результаты теста на периодичность последовательности при N=%d: %v\n", Row.N, Row.Repeat)
+	}
+	fmt.Println()
+
+	// Точные предпериод и период по целому состоянию генератора (алгоритм Брента)
+
+	// Для m=2^32 полный перебор занимает минуты, поэтому алгоритм
+	// демонстрируется на генераторах с модулями порядка 2^16, а полный
+	// период основного генератора гарантирован условиями Халла - Добелла
+	for _, Params := range []struct {
+		A, C, M, X0 uint64
+		Note        string
+	}{
+		{25173, 13849, 1 << 16, 1, "условия Халла - Добелла выполнены"},
+		{25173, 13848, 1 << 16, 1, "c четно"},
+		{5, 0, 1 << 16, 1, "мультипликативный генератор, период m/4"},
+		{6, 1, 1 << 16, 5, "a четно, есть предпериод"},
+		{16807, 0, 65521, 1, "простой модуль"},
+	} {
+		Small, err := NewLCG(Params.A, Params.C, Params.M, Params.X0)
+		if err != nil {
+			fmt.Println("Ошибка параметров генератора:", err)
+			continue
+		}
+		Period, err := Small.Cycle(0)
+		if err != nil {
+			fmt.Println("Ошибка поиска периода:", err)
+			continue
+		}
+		fmt.Printf("a=%d, c=%d, m=%d, x0=%d (%s): предпериод %d, период %d\n",
+			Params.A, Params.C, Params.M, Params.X0, Params.Note, Period.Mu, Period.Lambda)
+	}
+	fmt.Println()
 
 	// Задание 6
 
-	var K int = 10
 	resX := make([]float64, K)
 	for k := 0; k < K; k++ {
 		resX[k] = ((B - A) / float64(K)) * (0.5 + float64(k))
 	}
-	fmt.Println("Проверка функции GerFreqDistr:", resX, "\n")
+	fmt.Println("Проверка функции GerFreqDistr:", resX)
+	fmt.Println()
 
-	resY := GetFreqDistr(RParamsArr, A, B, K)
-	fmt.Println("Значение функции GerFreqDistr для последовательности при N=100:", resY)
+	for _, Row := range LabRows {
+		resY := GetFreqDistr(Row.Values, A, B, K)
+		fmt.Printf("Значение функции GerFreqDistr для последовательности при N=%d: %v\n", Row.N, resY)
 
-	var values plotter.Values
-	values = append(values, resY...)
-	hist, _ := plotter.NewBarChart(values, 10)
+		hist, _ := plotter.NewBarChart(plotter.Values(resY), 10)
+		pl := plot.New()
+		pl.Add(hist)
+		FileName := fmt.Sprintf("hist_e%d.png", int(math.Log10(float64(Row.N))))
+		pl.Save(5*vg.Inch, 5*vg.Inch, FileName)
+		fmt.Printf("Гистограмма относительных частот для последовательности случайных чисел длинной N=%d была сохранена в файл %s\n", Row.N, FileName)
 
-	pl := plot.New()
-	pl.Add(hist)
-	pl.Save(5*vg.Inch, 5*vg.Inch, "hist_e2.png")
-	fmt.Println("Гистограмма относительных частот для последовательности случайных чисел длинной N=100 была сохранена в файл hist_e2.png")
-
-	var pearsonCriterion_e2 float64
-	for i := 0; i < K; i++ {
-		pearsonCriterion_e2 += ((1/float64(K) - resY[i]) * (1/float64(K) - resY[i])) / resY[i]
-	}
-	fmt.Println("Критерий Пирсона для последовательности случайных чисел длинной N=100 равен:", pearsonCriterion_e2, "\n")
-
-	resY = GetFreqDistr(RParamsArr_e3, A, B, K)
-	fmt.Println("Значение функции GerFreqDistr для последовательности при N=1000:", resY)
-
-	values = values[:0]
-	values = append(values, resY...)
-	hist, _ = plotter.NewBarChart(values, 10)
-
-	pl = plot.New()
-	pl.Add(hist)
-	pl.Save(5*vg.Inch, 5*vg.Inch, "hist_e3.png")
-	fmt.Println("Гистограмма относительных частот для последовательности случайных чисел длинной N=1000 была сохранена в файл hist_e3.png")
-
-	var pearsonCriterion_e3 float64
-	for i := 0; i < K; i++ {
-		pearsonCriterion_e3 += ((1/float64(K) - resY[i]) * (1/float64(K) - resY[i])) / resY[i]
-	}
-	fmt.Println("Критерий Пирсона для последовательности случайных чисел длинной N=1000 равен:", pearsonCriterion_e3, "\n")
-
-	resY = GetFreqDistr(RParamsArr_e4, A, B, K)
-	fmt.Println("Значение функции GerFreqDistr для последовательности при N=10000:", resY)
-
-	values = values[:0]
-	values = append(values, resY...)
-	hist, _ = plotter.NewBarChart(values, 10)
-
-	pl = plot.New()
-	pl.Add(hist)
-	pl.Save(5*vg.Inch, 5*vg.Inch, "hist_e4.png")
-	fmt.Println("Гистограмма относительных частот для последовательности случайных чисел длинной N=10000 была сохранена в файл hist_e4.png")
-
-	var pearsonCriterion_e4 float64
-	for i := 0; i < K; i++ {
-		pearsonCriterion_e4 += ((1/float64(K) - resY[i]) * (1/float64(K) - resY[i])) / resY[i]
-	}
-	fmt.Println("Критерий Пирсона для последовательности случайных чисел длинной N=10000 равен:", pearsonCriterion_e4, "\n")
-
-	resY = GetFreqDistr(RParamsArr_e5, A, B, K)
-	fmt.Println("Значение функции GerFreqDistr для последовательности при N=100000:", resY)
-
-	values = values[:0]
-	values = append(values, resY...)
-	hist, _ = plotter.NewBarChart(values, 10)
-
-	pl = plot.New()
-	pl.Add(hist)
-	pl.Save(5*vg.Inch, 5*vg.Inch, "hist_e5.png")
-	fmt.Println("Гистограмма относительных частот для последовательности случайных чисел длинной N=100000 была сохранена в файл hist_e5.png")
-
-	var pearsonCriterion_e5 float64
-	for i := 0; i < K; i++ {
-		pearsonCriterion_e5 += ((1/float64(K) - resY[i]) * (1/float64(K) - resY[i])) / resY[i]
-	}
-	fmt.Println("Критерий Пирсона для последовательности случайных чисел длинной N=100000 равен:", pearsonCriterion_e5, "\n")
-
-	// Задание 7
-
-	N = 100
-
-	rand_RParamsArr_e2 := make([]float64, N)
-	for i := 0; i < N; i++ {
-		rand_RParamsArr_e2[i] = A + (B-A)*rand.Float64()
+		fmt.Printf("Критерий Пирсона для последовательности случайных чисел длинной N=%d: %v\n", Row.N, Row.ChiSquare)
+		fmt.Println()
 	}
 
-	fmt.Println("Максимальное значение при N=100 для встроенного генератора случайных чисел Go:", slices.Max(rand_RParamsArr_e2))
-	fmt.Println("Минимальное значение при N=100 для встроенного генератора случайных чисел Go:", slices.Min(rand_RParamsArr_e2), "\n")
+	// Критерий Колмогорова - Смирнова для равномерного распределения на [A;B]
 
-	N = 1000
-
-	rand_RParamsArr_e3 := make([]float64, N)
-	for i := 0; i < N; i++ {
-		rand_RParamsArr_e3[i] = A + (B-A)*rand.Float64()
+	UniformCDF := func(x float64) float64 {
+		return math.Min(math.Max((x-A)/(B-A), 0), 1)
 	}
-
-	fmt.Println("Максимальное значение при N=1000 для встроенного генератора случайных чисел Go:", slices.Max(rand_RParamsArr_e3))
-	fmt.Println("Минимальное значение при N=1000 для встроенного генератора случайных чисел Go:", slices.Min(rand_RParamsArr_e3), "\n")
-
-	N = 10000
-
-	rand_RParamsArr_e4 := make([]float64, N)
-	for i := 0; i < N; i++ {
-		rand_RParamsArr_e4[i] = A + (B-A)*rand.Float64()
+	for _, Row := range LabRows {
+		fmt.Printf("Критерий Колмогорова - Смирнова для последовательности длинной N=%d: %v\n", Row.N, Row.KS)
 	}
-
-	fmt.Println("Максимальное значение при N=10000 для встроенного генератора случайных чисел Go:", slices.Max(rand_RParamsArr_e4))
-	fmt.Println("Минимальное значение при N=10000 для встроенного генератора случайных чисел Go:", slices.Min(rand_RParamsArr_e4), "\n")
-
-	N = 100000
-
-	rand_RParamsArr_e5 := make([]float64, N)
-	for i := 0; i < N; i++ {
-		rand_RParamsArr_e5[i] = A + (B-A)*rand.Float64()
+	if err := stats.SaveECDFPlot(LabRows[1].Values, UniformCDF, Alpha, "Эмпирическая функция распределения (N=1000)", "ecdf_e3.png"); err != nil {
+		fmt.Println("Ошибка сохранения графика:", err)
+	} else {
+		fmt.Println("Эмпирическая функция распределения для последовательности длинной N=1000 была сохранена в файл ecdf_e3.png")
 	}
+	fmt.Println()
 
-	fmt.Println("Максимальное значение при N=100000 для встроенного генератора случайных чисел Go:", slices.Max(rand_RParamsArr_e5))
-	fmt.Println("Минимальное значение при N=100000 для встроенного генератора случайных чисел Go:", slices.Min(rand_RParamsArr_e5), "\n")
-
-	fmt.Println("Теоретическое мат. ожидание:", M)
-	fmt.Println("Теоретическая дисперсия:", D, "\n")
-
-	N = 100
+	// Батарея эмпирических тестов случайности
 
-	var rand_M_e2 float64
-	for i := 0; i < N; i++ {
-		rand_M_e2 += rand_RParamsArr_e2[i]
-	}
-	rand_M_e2 /= float64(N)
-	fmt.Println("Мат. ожидание при N=100 для встроенного генератора случайных чисел Go:", rand_M_e2)
-
-	var rand_EpsM1 float64 = math.Abs((M-rand_M_e2)/M) * 100
-	fmt.Println("Погрешность мат. ожидания при N=100 для встроенного генератора случайных чисел Go:", rand_EpsM1)
+	Gen.Seed(StartX)
+	PrintBattery(Gen.String(), RunBattery(Gen.Float64, DefaultBatteryConfig))
+	fmt.Println()
+	PrintBattery("math/rand", RunBattery(rand.Float64, DefaultBatteryConfig))
+	fmt.Println()
 
-	var rand_D_e2 float64
-	for i := 0; i < N; i++ {
-		rand_D_e2 += rand_RParamsArr_e2[i] * rand_RParamsArr_e2[i]
-	}
-	rand_D_e2 /= float64(N)
-	rand_D_e2 -= rand_M_e2 * rand_M_e2
-	rand_D_e2 *= float64(N) / (float64(N) - 1)
-	fmt.Println("Дисперсия при N=100 для встроенного генератора случайных чисел Go:", rand_D_e2)
+	// Тесты NIST SP 800-22 на потоке из 10^6 бит
 
-	var rand_EpsD1 float64 = math.Abs((D-rand_D_e2)/D) * 100
-	fmt.Println("Погрешность дисперсии при N=100 для встроенного генератора случайных чисел Go:", rand_EpsD1, "\n")
+	Gen.Seed(StartX)
+	PrintNIST(Gen.String(), RunNIST(Bits(Gen.Uint32, 1000000), Alpha))
+	fmt.Println()
+	PrintNIST("math/rand", RunNIST(Bits(rand.Uint32, 1000000), Alpha))
+	fmt.Println()
 
-	N = 1000
+	// Каталог генераторов с общим интерфейсом Generator
 
-	var rand_M_e3 float64
-	for i := 0; i < N; i++ {
-		rand_M_e3 += rand_RParamsArr_e3[i]
+	Checks := GeneratorChecks()
+	PrintGeneratorChecks(Checks)
+	if !ChecksPassed(Checks) {
+		fmt.Println("Внимание: результаты ниже для несовпадающих генераторов недостоверны")
 	}
-	rand_M_e3 /= float64(N)
-	fmt.Println("Мат. ожидание при N=1000 для встроенного генератора случайных чисел Go:", rand_M_e3)
+	fmt.Println()
 
-	var rand_EpsM2 float64 = math.Abs((M-rand_M_e3)/M) * 100
-	fmt.Println("Погрешность мат. ожидания при N=1000 для встроенного генератора случайных чисел Go:", rand_EpsM2)
+	// Спектральный тест параметров ЛКГ: ν_t - длина кратчайшего вектора
+	// двойственной решетки, 1/ν_t - расстояние между гиперплоскостями,
+	// на которых лежат наборы (u_n, ..., u_{n+t-1}); S_t близко к 1 у
+	// хороших множителей
 
-	var rand_D_e3 float64
-	for i := 0; i < N; i++ {
-		rand_D_e3 += rand_RParamsArr_e3[i] * rand_RParamsArr_e3[i]
+	SpectralParams := []struct {
+		Name string
+		A, M uint64
+	}{
+		{Gen.String() + " (генератор работы)", Gen.A, Gen.M},
+		{"a=39, m=1000 (генератор работ 4 и 7)", 39, 1000},
+		{"a=16807, m=2^31-1 (MINSTD)", MinstdA0, 1<<31 - 1},
+		{"a=65539, m=2^31 (RANDU)", 65539, 1 << 31},
 	}
-	rand_D_e3 /= float64(N)
-	rand_D_e3 -= rand_M_e3 * rand_M_e3
-	rand_D_e3 *= float64(N) / (float64(N) - 1)
-	fmt.Println("Дисперсия при N=1000 для встроенного генератора случайных чисел Go:", rand_D_e3)
-
-	var rand_EpsD2 float64 = math.Abs((D-rand_D_e3)/D) * 100
-	fmt.Println("Погрешность дисперсии при N=1000 для встроенного генератора случайных чисел Go:", rand_EpsD2, "\n")
-
-	N = 10000
-
-	var rand_M_e4 float64
-	for i := 0; i < N; i++ {
-		rand_M_e4 += rand_RParamsArr_e4[i]
-	}
-	rand_M_e4 /= float64(N)
-	fmt.Println("Мат. ожидание при N=10000 для встроенного генератора случайных чисел Go:", rand_M_e4)
-
-	var rand_EpsM3 float64 = math.Abs((M-rand_M_e4)/M) * 100
-	fmt.Println("Погрешность мат. ожидания при N=10000 для встроенного генератора случайных чисел Go:", rand_EpsM3)
-
-	var rand_D_e4 float64
-	for i := 0; i < N; i++ {
-		rand_D_e4 += rand_RParamsArr_e4[i] * rand_RParamsArr_e4[i]
+	Spectral := make([][]SpectralResult, len(SpectralParams))
+	for i, Params := range SpectralParams {
+		Spectral[i], err = SpectralTest(Params.A, Params.M, spectralMaxDim)
+		if err != nil {
+			fmt.Println("Ошибка спектрального теста:", err)
+			return
+		}
+		PrintSpectralTest(Params.Name, Spectral[i])
+		fmt.Println()
+	}
+
+	// Решетчатая структура пар и троек соседних чисел
+	Gen.Seed(StartX)
+	LatticeMain := Gen.Floats(3000)
+	SmallGen, _ := NewLCG(39, 1, 1000, 1)
+	LatticeSmall := SmallGen.Floats(1000)
+	Randu, _ := NewLCG(65539, 0, 1<<31, 1)
+	LatticeRandu := Randu.Floats(3000)
+	normal3 := func(r []SpectralResult) [3]float64 {
+		var n [3]float64
+		for k, s := range r[1].Vector {
+			n[k], _ = new(big.Float).SetInt(s).Float64()
+		}
+		return n
+	}
+	for _, Plot := range []struct {
+		Err error
+		Msg string
+	}{
+		{SaveLatticePlot2D(LatticeSmall, "Пары (u_n, u_{n+1}), a=39, c=1, m=1000", "lattice_2d_39_1000.png"), "lattice_2d_39_1000.png"},
+		{SaveLatticePlot2D(LatticeMain, "Пары (u_n, u_{n+1}), "+Gen.String(), "lattice_2d_main.png"), "lattice_2d_main.png"},
+		{SaveLatticePlot3D(LatticeRandu, normal3(Spectral[3]), "Тройки RANDU вдоль плоскостей 9x - 6y + z = k", "lattice_3d_randu.png"), "lattice_3d_randu.png"},
+		{SaveLatticePlot3D(LatticeMain, normal3(Spectral[0]), "Тройки "+Gen.String()+" вдоль кратчайшего вектора", "lattice_3d_main.png"), "lattice_3d_main.png"},
+	} {
+		if Plot.Err != nil {
+			fmt.Println("Ошибка при сохранении графика решетки:", Plot.Err)
+		} else {
+			fmt.Println("График решетки сохранен в", Plot.Msg)
+		}
 	}
-	rand_D_e4 /= float64(N)
-	rand_D_e4 -= rand_M_e4 * rand_M_e4
-	rand_D_e4 *= float64(N) / (float64(N) - 1)
-	fmt.Println("Дисперсия при N=10000 для встроенного генератора случайных чисел Go:", rand_D_e4)
-
-	var rand_EpsD3 float64 = math.Abs((D-rand_D_e4)/D) * 100
-	fmt.Println("Погрешность дисперсии при N=10000 для встроенного генератора случайных чисел Go:", rand_EpsD3, "\n")
+	fmt.Println()
 
-	N = 100000
+	// Каталог исторических ЛКГ: одни и те же тесты для хороших и плохих
+	// параметров. Серийный тест троек на сетке 32x32x32 чувствителен к
+	// плоскостям, на которых лежат тройки RANDU.
 
-	var rand_M_e5 float64
-	for i := 0; i < N; i++ {
-		rand_M_e5 += rand_RParamsArr_e5[i]
+	fmt.Println("Каталог линейных конгруэнтных генераторов:")
+	for _, Preset := range LCGPresets {
+		fmt.Printf("%-8s %s: a=%d, c=%d, m=%d\n", Preset.Name, Preset.Title, Preset.A, Preset.C, Preset.M)
+		fmt.Printf("         период: %s\n", Preset.Period)
+		fmt.Printf("         недостатки: %s\n", Preset.Weakness)
 	}
-	rand_M_e5 /= float64(N)
-	fmt.Println("Мат. ожидание при N=100000 для встроенного генератора случайных чисел Go:", rand_M_e5)
+	fmt.Println()
 
-	var rand_EpsM4 float64 = math.Abs((M-rand_M_e5)/M) * 100
-	fmt.Println("Погрешность мат. ожидания при N=100000 для встроенного генератора случайных чисел Go:", rand_EpsM4)
-
-	var rand_D_e5 float64
-	for i := 0; i < N; i++ {
-		rand_D_e5 += rand_RParamsArr_e5[i] * rand_RParamsArr_e5[i]
+	fmt.Printf("%-8s %16s %10s %14s %8s %8s\n", "Имя", "Период", "Батарея", "p (32x32x32)", "S_3", "min S_t")
+	for _, Preset := range LCGPresets {
+		PresetGen, err := NewLCGByName(Preset.Name, uint64(x0))
+		if err != nil {
+			fmt.Println("Ошибка параметров генератора:", err)
+			continue
+		}
+		Passed := 0
+		Results := RunBattery(PresetGen.Float64, DefaultBatteryConfig)
+		for _, r := range Results {
+			if r.Pass {
+				Passed++
+			}
+		}
+		_, TriplesP := SerialTest(PresetGen.Float64, 3*32*32*32*10, 3, 32)
+		PresetSpectral, err := PresetGen.SpectralTest(spectralMaxDim)
+		if err != nil {
+			fmt.Println("Ошибка спектрального теста:", err)
+			continue
+		}
+		MinMerit := 1.0
+		for _, r := range PresetSpectral {
+			MinMerit = math.Min(MinMerit, r.Merit)
+		}
+		Period, err := PresetGen.Period()
+		if err != nil {
+			fmt.Println("Ошибка вычисления периода:", err)
+			continue
+		}
+		fmt.Printf("%-8s %16d %6d/%-3d %14.4g %8.4f %8.4f\n",
+			Preset.Name, Period, Passed, len(Results), TriplesP, PresetSpectral[1].Merit, MinMerit)
+	}
+	fmt.Println()
+
+	// Разбиение периода на непересекающиеся потоки скачком за O(log k)
+	// для параллельных повторений эксперимента. Разбиение отклоняется,
+	// если потоки связаны сдвигом или отражением: так происходит для
+	// генератора с m = 2^e и для MINSTD при четырех потоках
+
+	if _, _, err := Gen.Substreams(4); err != nil {
+		fmt.Printf("Потоки генератора %s: %v\n", Gen, err)
+	}
+	Minstd, err := NewLCGByName("minstd", uint64(x0))
+	if err != nil {
+		fmt.Println("Ошибка параметров генератора:", err)
+		return
+	}
+	if _, _, err := Minstd.Substreams(4); err != nil {
+		fmt.Printf("Четыре потока %s: %v\n", Minstd, err)
+	}
+	Streams, BlockLen, err := Minstd.Substreams(3)
+	if err != nil {
+		fmt.Println("Ошибка разбиения на потоки:", err)
+		return
+	}
+	fmt.Printf("Период %s разбит на %d потока по %d чисел:\n", Minstd, len(Streams), BlockLen)
+	const StreamN = 100000
+	StreamValues := make([][]float64, len(Streams))
+	for i, Stream := range Streams {
+		Start := Stream.X
+		StreamValues[i] = Stream.Floats(StreamN)
+		Sum := 0.0
+		for _, u := range StreamValues[i] {
+			Sum += u
+		}
+		fmt.Printf("Поток %d: x_%d = %d, среднее %d чисел: %.4f", i, uint64(i)*BlockLen, Start, StreamN, Sum/float64(StreamN))
+		if i > 0 {
+			r, Lag := MaxCrossCorrelation(StreamValues[i], StreamValues[0], SubstreamMaxLag)
+			fmt.Printf(", наибольшая корреляция с потоком 0: %+.4f (сдвиг %d)", r, Lag)
+		}
+		fmt.Println()
+	}
+	// Поправка Бонферрони на число проверенных сдвигов
+	Lags := 2*SubstreamMaxLag + 1
+	fmt.Printf("При отсутствии корреляции на сдвигах от %d до %d наибольшее |r| не превышает %.4f с вероятностью 0.95\n",
+		-SubstreamMaxLag, SubstreamMaxLag, math.Sqrt2*math.Erfinv(1-0.05/float64(Lags))/math.Sqrt(StreamN))
+	fmt.Println()
+
+	// Анализ отдельных бит состояния: при m = 2^e бит k имеет период
+	// не больше 2^(k+1), и младшие биты непригодны для использования
+
+	const BitSamples = 1 << 18
+	BitWidth := bits.Len64(Gen.M - 1)
+	Gen.Seed(StartX)
+	BitStatsArr := AnalyzeBits(Gen.Next, BitWidth, BitSamples, 16, Alpha)
+	PrintBitStats(Gen.String(), BitStatsArr)
+	var SafeBits []int
+	for _, St := range BitStatsArr {
+		if St.Safe {
+			SafeBits = append(SafeBits, St.Bit)
+		}
 	}
-	rand_D_e5 /= float64(N)
-	rand_D_e5 -= rand_M_e5 * rand_M_e5
-	rand_D_e5 *= float64(N) / (float64(N) - 1)
-	fmt.Println("Дисперсия при N=100000 для встроенного генератора случайных чисел Go:", rand_D_e5)
-
-	var rand_EpsD4 float64 = math.Abs((D-rand_D_e5)/D) * 100
-	fmt.Println("Погрешность дисперсии при N=100000 для встроенного генератора случайных чисел Go:", rand_EpsD4, "\n")
-
-	var rand_TEST_1 = RANDPeriod(rand_RParamsArr_e2)
-	fmt.Println("Результаты теста на периодичность последовательности при N=100 для встроенного генератора случайных чисел Go:", rand_TEST_1)
-	var rand_TEST_2 = RANDPeriod(rand_RParamsArr_e3)
-	fmt.Println("Результаты теста на периодичность последовательности при N=1000 для встроенного генератора случайных чисел Go:", rand_TEST_2)
-	var rand_TEST_3 = RANDPeriod(rand_RParamsArr_e4)
-	fmt.Println("Результаты теста на периодичность последовательности при N=10000 для встроенного генератора случайных чисел Go:", rand_TEST_3)
-	var rand_TEST_4 = RANDPeriod(rand_RParamsArr_e5)
-	fmt.Println("Результаты теста на периодичность последовательности при N=100000 для встроенного генератора случайных чисел Go:", rand_TEST_4, "\n")
-
-	resY = GetFreqDistr(rand_RParamsArr_e2, A, B, K)
-	fmt.Println("Значение функции GerFreqDistr для последовательности при N=100 для встроенного генератора случайных чисел Go:", resY)
-
-	values = values[:0]
-	values = append(values, resY...)
-	hist, _ = plotter.NewBarChart(values, 10)
-
-	pl = plot.New()
-	pl.Add(hist)
-	pl.Save(5*vg.Inch, 5*vg.Inch, "rand_hist_e2.png")
-	fmt.Println("Гистограмма относительных частот для последовательности случайных чисел длинной N=100 для встроенного генератора случайных чисел Go была сохранена в файл rand_hist_e2.png")
-
-	var rand_pearsonCriterion_e2 float64
-	for i := 0; i < K; i++ {
-		rand_pearsonCriterion_e2 += ((1/float64(K) - resY[i]) * (1/float64(K) - resY[i])) / resY[i]
+	fmt.Printf("Биты, прошедшие все проверки (уровень одного теста %.2g, для всех %d тестов %g): %v\n",
+		BitTestLevel(Alpha, BitWidth), 2*BitWidth, Alpha, SafeBits)
+	// Период бита обнаруживается, если он не длиннее половины выборки
+	MaxDetect := bits.Len(BitSamples/2) - 1
+	fmt.Printf("Периоды длиннее половины выборки (2^%d) не обнаруживаются", MaxDetect)
+	if bits.OnesCount64(Gen.M) <= 1 && Gen.Check.FullPeriod && MaxDetect < BitWidth {
+		fmt.Printf(": при m = 2^%d и полном периоде бит %d имеет период 2^%d", bits.TrailingZeros64(Gen.M), MaxDetect, MaxDetect+1)
 	}
-	fmt.Println("Критерий Пирсона для последовательности случайных чисел длинной N=100 для встроенного генератора случайных чисел Go равен:", rand_pearsonCriterion_e2, "\n")
-
-	resY = GetFreqDistr(rand_RParamsArr_e3, A, B, K)
-	fmt.Println("Значение функции GerFreqDistr для последовательности при N=1000 для встроенного генератора случайных чисел Go:", resY)
-
-	values = values[:0]
-	values = append(values, resY...)
-	hist, _ = plotter.NewBarChart(values, 10)
-
-	pl = plot.New()
-	pl.Add(hist)
-	pl.Save(5*vg.Inch, 5*vg.Inch, "rand_hist_e3.png")
-	fmt.Println("Гистограмма относительных частот для последовательности случайных чисел длинной N=1000 для встроенного генератора случайных чисел Go была сохранена в файл rand_hist_e3.png")
-
-	var rand_pearsonCriterion_e3 float64
-	for i := 0; i < K; i++ {
-		rand_pearsonCriterion_e3 += ((1/float64(K) - resY[i]) * (1/float64(K) - resY[i])) / resY[i]
+	fmt.Println()
+	if err := SaveBitHeatmap(BitStatsArr, BitSamples, Alpha, "Качество бит "+Gen.String(), "bits_heatmap.png"); err != nil {
+		fmt.Println("Ошибка сохранения тепловой карты:", err)
+	} else {
+		fmt.Println("Тепловая карта качества бит сохранена в файл bits_heatmap.png")
 	}
-	fmt.Println("Критерий Пирсона для последовательности случайных чисел длинной N=1000 для встроенного генератора случайных чисел Go равен:", rand_pearsonCriterion_e3, "\n")
-
-	resY = GetFreqDistr(rand_RParamsArr_e4, A, B, K)
-	fmt.Println("Значение функции GerFreqDistr для последовательности при N=10000 для встроенного генератора случайных чисел Go:", resY)
-
-	values = values[:0]
-	values = append(values, resY...)
-	hist, _ = plotter.NewBarChart(values, 10)
+	fmt.Println()
 
-	pl = plot.New()
-	pl.Add(hist)
-	pl.Save(5*vg.Inch, 5*vg.Inch, "rand_hist_e4.png")
-	fmt.Println("Гистограмма относительных частот для последовательности случайных чисел длинной N=10000 для встроенного генератора случайных чисел Go была сохранена в файл rand_hist_e4.png")
+	// Задание 7: сравнение генератора работы со встроенным генератором Go
+	// и генераторами из каталога по одним и тем же метрикам
 
-	var rand_pearsonCriterion_e4 float64
-	for i := 0; i < K; i++ {
-		rand_pearsonCriterion_e4 += ((1/float64(K) - resY[i]) * (1/float64(K) - resY[i])) / resY[i]
+	fmt.Println("Теоретическое мат. ожидание:", M)
+	fmt.Println("Теоретическая дисперсия:", D)
+	PrintComparison(Rows)
+	for _, Size := range Sizes {
+		FileName := fmt.Sprintf("hist_compare_%d.png", Size)
+		if err := SaveComparisonHistograms(Rows, Size, CompareCfg, FileName); err != nil {
+			fmt.Println("Ошибка сохранения гистограмм:", err)
+		} else {
+			fmt.Printf("Гистограммы генераторов для N=%d сохранены в файл %s\n", Size, FileName)
+		}
 	}
-	fmt.Println("Критерий Пирсона для последовательности случайных чисел длинной N=10000 для встроенного генератора случайных чисел Go равен:", rand_pearsonCriterion_e4, "\n")
-
-	resY = GetFreqDistr(rand_RParamsArr_e5, A, B, K)
-	fmt.Println("Значение функции GerFreqDistr для последовательности при N=100000 для встроенного генератора случайных чисел Go:", resY)
-
-	values = values[:0]
-	values = append(values, resY...)
-	hist, _ = plotter.NewBarChart(values, 10)
-
-	pl = plot.New()
-	pl.Add(hist)
-	pl.Save(5*vg.Inch, 5*vg.Inch, "rand_hist_e5.png")
-	fmt.Println("Гистограмма относительных частот для последовательности случайных чисел длинной N=100000 для встроенного генератора случайных чисел Go была сохранена в файл rand_hist_e5.png")
+	fmt.Println()
+}
 
-	var rand_pearsonCriterion_e5 float64
-	for i := 0; i < K; i++ {
-		rand_pearsonCriterion_e5 += ((1/float64(K) - resY[i]) * (1/float64(K) - resY[i])) / resY[i]
-	}
-	fmt.Println("Критерий Пирсона для последовательности случайных чисел длинной N=100000 для встроенного генератора случайных чисел Go равен:", rand_pearsonCriterion_e5, "\n")
+// Вспомогательная структура для цвета
+type color struct {
+	R, G, B uint8
+}
 
+// Метод для преобразования цвета в RGBA
+func (c color) RGBA() (r, g, b, a uint32) {
+	r = uint32(c.R)
+	r |= r << 8
+	g = uint32(c.G)
+	g |= g << 8
+	b = uint32(c.B)
+	b |= b << 8
+	a = uint32(255)
+	a |= a << 8
+	return
 }
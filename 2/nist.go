@@ -0,0 +1,253 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// WordSource - источник 32-битных слов
+type WordSource func() uint32
+
+// Uint32 - следующее число генератора, приведенное к 32 битам: старшие
+// 32 бита дроби x/m. Для m = 2^32 это само состояние, для меньших
+// модулей младшие биты слова всегда нулевые.
+func (g *LCG) Uint32() uint32 {
+	return uint32(g.Float64() * (1 << 32))
+}
+
+// Bits - n бит из последовательности слов, старший бит каждого слова первым
+func Bits(src WordSource, n int) []uint8 {
+	bits := make([]uint8, n)
+	var w uint32
+	for i := range bits {
+		if i%32 == 0 {
+			w = src()
+		}
+		bits[i] = uint8(w >> 31)
+		w <<= 1
+	}
+	return bits
+}
+
+// WriteRaw - запись count слов в w в виде 32-битных целых в порядке
+// little-endian (формат stdin_input_raw для dieharder -g 200 и
+// "RNG_test stdin32" для PractRand); count == 0 - бесконечный поток
+func WriteRaw(w io.Writer, src WordSource, count int) error {
+	buf := bufio.NewWriterSize(w, 1<<16)
+	var word [4]byte
+	for i := 0; count == 0 || i < count; i++ {
+		binary.LittleEndian.PutUint32(word[:], src())
+		if _, err := buf.Write(word[:]); err != nil {
+			return err
+		}
+	}
+	return buf.Flush()
+}
+
+// RunNIST - тесты из набора NIST SP 800-22: частотный, частотный в блоках,
+// серий, самой длинной серии единиц в блоке, кумулятивных сумм (в прямом
+// и обратном направлении) и приближенной энтропии
+func RunNIST(bits []uint8, alpha float64) []TestResult {
+	forward, backward := CumulativeSumsTest(bits)
+	tests := []struct {
+		name string
+		p    float64
+	}{
+		{"Частотный (monobit)", FrequencyTest(bits)},
+		{"Частотный в блоках (M=128)", BlockFrequencyTest(bits, 128)},
+		{"Серий (runs)", RunsTest(bits)},
+		{"Самой длинной серии единиц", LongestRunTest(bits)},
+		{"Кумулятивных сумм (прямой)", forward},
+		{"Кумулятивных сумм (обратный)", backward},
+		{"Приближенной энтропии (m=8)", ApproximateEntropyTest(bits, 8)},
+	}
+	results := make([]TestResult, len(tests))
+	for i, t := range tests {
+		results[i] = TestResult{Name: t.name, Statistic: math.NaN(), PValue: t.p, Pass: t.p >= alpha}
+	}
+	return results
+}
+
+// FrequencyTest - частотный тест: доля единиц близка к 1/2
+func FrequencyTest(bits []uint8) float64 {
+	var s int
+	for _, b := range bits {
+		s += 2*int(b) - 1
+	}
+	sObs := math.Abs(float64(s)) / math.Sqrt(float64(len(bits)))
+	return math.Erfc(sObs / math.Sqrt2)
+}
+
+// BlockFrequencyTest - частотный тест в неперекрывающихся блоках по m бит
+func BlockFrequencyTest(bits []uint8, m int) float64 {
+	blocks := len(bits) / m
+	var chi2 float64
+	for i := 0; i < blocks; i++ {
+		ones := 0
+		for _, b := range bits[i*m : (i+1)*m] {
+			ones += int(b)
+		}
+		d := float64(ones)/float64(m) - 0.5
+		chi2 += d * d
+	}
+	chi2 *= 4 * float64(m)
+	return GammaQ(float64(blocks)/2, chi2/2)
+}
+
+// RunsTest - тест серий: общее количество серий одинаковых бит
+func RunsTest(bits []uint8) float64 {
+	n := float64(len(bits))
+	ones := 0
+	for _, b := range bits {
+		ones += int(b)
+	}
+	pi := float64(ones) / n
+	// Предварительное условие: частотный тест должен быть пройден
+	if math.Abs(pi-0.5) >= 2/math.Sqrt(n) {
+		return 0
+	}
+	v := 1
+	for k := 1; k < len(bits); k++ {
+		if bits[k] != bits[k-1] {
+			v++
+		}
+	}
+	num := math.Abs(float64(v) - 2*n*pi*(1-pi))
+	return math.Erfc(num / (2 * math.Sqrt(2*n) * pi * (1 - pi)))
+}
+
+// LongestRunTest - тест самой длинной серии единиц в блоке; размер блока
+// и вероятности классов выбираются по длине последовательности (SP 800-22, 2.4)
+func LongestRunTest(bits []uint8) float64 {
+	var m, lo int
+	var probs []float64
+	switch n := len(bits); {
+	case n >= 750000:
+		m, lo = 10000, 10
+		probs = []float64{0.0882, 0.2092, 0.2483, 0.1933, 0.1208, 0.0675, 0.0727}
+	case n >= 6272:
+		m, lo = 128, 4
+		probs = []float64{0.1174, 0.2430, 0.2493, 0.1752, 0.1027, 0.1124}
+	case n >= 128:
+		m, lo = 8, 1
+		probs = []float64{0.2148, 0.3672, 0.2305, 0.1875}
+	default:
+		return math.NaN()
+	}
+	k := len(probs) - 1
+	blocks := len(bits) / m
+	counts := make([]float64, k+1)
+	for i := 0; i < blocks; i++ {
+		longest, run := 0, 0
+		for _, b := range bits[i*m : (i+1)*m] {
+			if b == 1 {
+				run++
+				longest = max(longest, run)
+			} else {
+				run = 0
+			}
+		}
+		counts[min(max(longest-lo, 0), k)]++
+	}
+	var chi2 float64
+	for i, p := range probs {
+		e := float64(blocks) * p
+		chi2 += (counts[i] - e) * (counts[i] - e) / e
+	}
+	return GammaQ(float64(k)/2, chi2/2)
+}
+
+// CumulativeSumsTest - тест кумулятивных сумм случайного блуждания ±1
+// в прямом и обратном направлении
+func CumulativeSumsTest(bits []uint8) (forward, backward float64) {
+	n := len(bits)
+	var s, zf int
+	for _, b := range bits {
+		s += 2*int(b) - 1
+		zf = max(zf, abs(s))
+	}
+	s = 0
+	var zb int
+	for i := n - 1; i >= 0; i-- {
+		s += 2*int(bits[i]) - 1
+		zb = max(zb, abs(s))
+	}
+	return cusumPValue(n, zf), cusumPValue(n, zb)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// cusumPValue - p-значение для максимального отклонения z блуждания длины n
+func cusumPValue(n, z int) float64 {
+	if z == 0 {
+		return 1
+	}
+	fn, fz := float64(n), float64(z)
+	sqn := math.Sqrt(fn)
+	phi := func(x float64) float64 { return 0.5 * math.Erfc(-x/math.Sqrt2) }
+
+	var sum1, sum2 float64
+	for k := int(math.Floor((-fn/fz + 1) / 4)); k <= int(math.Floor((fn/fz-1)/4)); k++ {
+		fk := float64(k)
+		sum1 += phi((4*fk+1)*fz/sqn) - phi((4*fk-1)*fz/sqn)
+	}
+	for k := int(math.Floor((-fn/fz - 3) / 4)); k <= int(math.Floor((fn/fz-1)/4)); k++ {
+		fk := float64(k)
+		sum2 += phi((4*fk+3)*fz/sqn) - phi((4*fk+1)*fz/sqn)
+	}
+	return math.Min(math.Max(1-sum1+sum2, 0), 1)
+}
+
+// ApproximateEntropyTest - тест приближенной энтропии: частоты
+// перекрывающихся шаблонов длины m и m+1 (с циклическим продолжением)
+func ApproximateEntropyTest(bits []uint8, m int) float64 {
+	n := len(bits)
+	phi := func(m int) float64 {
+		if m == 0 {
+			return 0
+		}
+		counts := make([]int, 1<<m)
+		for i := 0; i < n; i++ {
+			idx := 0
+			for j := 0; j < m; j++ {
+				idx = idx<<1 | int(bits[(i+j)%n])
+			}
+			counts[idx]++
+		}
+		var sum float64
+		for _, c := range counts {
+			if c > 0 {
+				p := float64(c) / float64(n)
+				sum += p * math.Log(p)
+			}
+		}
+		return sum
+	}
+	apEn := phi(m) - phi(m+1)
+	chi2 := 2 * float64(n) * (math.Ln2 - apEn)
+	return GammaQ(math.Pow(2, float64(m-1)), chi2/2)
+}
+
+// PrintNIST - таблица p-значений тестов NIST
+func PrintNIST(name string, results []TestResult) {
+	fmt.Printf("Тесты NIST SP 800-22 для генератора %s:\n", name)
+	fmt.Printf("%-32s %10s %10s\n", "Тест", "p", "Результат")
+	passed := 0
+	for _, r := range results {
+		verdict := "не пройден"
+		if r.Pass {
+			verdict = "пройден"
+			passed++
+		}
+		fmt.Printf("%-32s %10.4f %10s\n", r.Name, r.PValue, verdict)
+	}
+	fmt.Printf("Пройдено тестов: %d из %d\n", passed, len(results))
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// LCGPreset - именованный набор параметров линейного конгруэнтного
+// генератора из известных библиотек и книг
+type LCGPreset struct {
+	Name     string // Имя для выбора генератора (без учета регистра)
+	Title    string // Происхождение параметров
+	A, C, M  uint64 // Множитель, приращение и модуль (0 - 2^64)
+	Period   string // Период последовательности состояний
+	Weakness string // Известные недостатки
+	Scramble uint64 // Маска, с которой начальное значение складывается по XOR
+}
+
+// LCGPresets - каталог исторических параметров. Библиотечные функции
+// обычно выдают не всё состояние, а его старшие биты (это указано в
+// описании); генератор LCG возвращает состояние целиком, поэтому слабость
+// младших бит у модулей 2^e проявляется в полной мере.
+var LCGPresets = []LCGPreset{
+	{
+		Name: "minstd0", Title: "Парк и Миллер (1988), C++ minstd_rand0",
+		A: 16807, C: 0, M: 1<<31 - 1,
+		Period:   "2^31 - 2 (a - первообразный корень по простому модулю)",
+		Weakness: "слабая структура пар: S_2 = 0.34, пары лежат на прямых через 1/16807",
+	},
+	{
+		Name: "minstd", Title: "Парк, Миллер и Стокмейер (1993), C++ minstd_rand",
+		A: 48271, C: 0, M: 1<<31 - 1,
+		Period:   "2^31 - 2",
+		Weakness: "короткий период по современным меркам, 31 бит на число",
+	},
+	{
+		Name: "glibc", Title: "glibc random() с состоянием TYPE_0, пример rand из стандарта ANSI C",
+		A: 1103515245, C: 12345, M: 1 << 31,
+		Period:   "2^31; младший бит состояния чередуется, бит k имеет период 2^(k+1)",
+		Weakness: "TYPE_0 выдает все 31 бит состояния, младшие биты предсказуемы",
+	},
+	{
+		Name: "msvc", Title: "Microsoft Visual C++ rand",
+		A: 214013, C: 2531011, M: 1 << 32,
+		Period:   "2^32",
+		Weakness: "библиотека выдает лишь 15 бит (30..16) состояния; младшие биты с короткими периодами",
+	},
+	{
+		Name: "nr", Title: "Numerical Recipes, ranqd1",
+		A: 1664525, C: 1013904223, M: 1 << 32,
+		Period:   "2^32",
+		Weakness: "младшие биты с короткими периодами; авторы рекомендуют его только как быстрый генератор",
+	},
+	{
+		Name: "borland", Title: "Borland C/C++ rand (генератор этой работы)",
+		A: 22695477, C: 1, M: 1 << 32,
+		Period:   "2^32",
+		Weakness: "библиотека выдает биты 30..16 состояния; младшие биты с короткими периодами",
+	},
+	{
+		Name: "java", Title: "java.util.Random (начальное значение складывается по XOR с 0x5DEECE66D)",
+		A: 0x5DEECE66D, C: 11, M: 1 << 48,
+		Period:   "2^48",
+		Weakness: "nextInt выдает биты 47..16; по двум выходам состояние восстанавливается перебором 2^16 вариантов",
+		Scramble: 0x5DEECE66D,
+	},
+	{
+		Name: "randu", Title: "IBM RANDU (1960-е)",
+		A: 65539, C: 0, M: 1 << 31,
+		Period:   "2^29 при нечетном начальном значении",
+		Weakness: "x_{n+2} = 6x_{n+1} - 9x_n (mod 2^31): тройки лежат на 15 плоскостях",
+	},
+}
+
+// FindLCGPreset - набор параметров по имени (без учета регистра)
+func FindLCGPreset(name string) (LCGPreset, error) {
+	for _, p := range LCGPresets {
+		if strings.EqualFold(p.Name, name) {
+			return p, nil
+		}
+	}
+	names := make([]string, len(LCGPresets))
+	for i, p := range LCGPresets {
+		names[i] = p.Name
+	}
+	return LCGPreset{}, fmt.Errorf("неизвестный генератор %q, доступны: %s", name, strings.Join(names, ", "))
+}
+
+// NewLCGByName - генератор с параметрами из каталога LCGPresets.
+// Начальное значение перемешивается маской Scramble (как в конструкторе
+// java.util.Random) и приводится по модулю m; для мультипликативных
+// генераторов оно должно быть ненулевым, а при m = 2^e - нечетным.
+func NewLCGByName(name string, seed uint64) (*LCG, error) {
+	p, err := FindLCGPreset(name)
+	if err != nil {
+		return nil, err
+	}
+	seed ^= p.Scramble
+	if p.M != 0 {
+		seed %= p.M
+	}
+	if p.C == 0 {
+		if seed == 0 {
+			return nil, fmt.Errorf("начальное значение мультипликативного генератора %s должно быть ненулевым", p.Name)
+		}
+		if bits.OnesCount64(p.M) <= 1 && seed%2 == 0 {
+			return nil, fmt.Errorf("начальное значение генератора %s должно быть нечетным", p.Name)
+		}
+	}
+	return NewLCG(p.A, p.C, p.M, seed)
+}
+
+// String - запись рекуррентного соотношения генератора
+func (g *LCG) String() string {
+	mod := "2^64"
+	if g.M != 0 {
+		mod = fmt.Sprint(g.M)
+		if bits.OnesCount64(g.M) == 1 {
+			mod = fmt.Sprintf("2^%d", bits.TrailingZeros64(g.M))
+		}
+	}
+	if g.C == 0 {
+		return fmt.Sprintf("x_{i+1} = %d*x_i mod %s", g.A, mod)
+	}
+	return fmt.Sprintf("x_{i+1} = (%d*x_i + %d) mod %s", g.A, g.C, mod)
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"math/bits"
+	"slices"
+)
+
+// mulMod - произведение a*b по модулю m без переполнения (128-битное
+// промежуточное значение)
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, m)
+}
+
+// powMod - возведение a в степень e по модулю m
+func powMod(a, e, m uint64) uint64 {
+	result := uint64(1) % m
+	a %= m
+	for e > 0 {
+		if e&1 == 1 {
+			result = mulMod(result, a, m)
+		}
+		a = mulMod(a, a, m)
+		e >>= 1
+	}
+	return result
+}
+
+// gcd - наибольший общий делитель (алгоритм Евклида)
+func gcd(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// IsPrime - тест Миллера - Рабина; набор из первых 12 простых оснований
+// дает точный ответ для всех n < 2^64
+func IsPrime(n uint64) bool {
+	if n < 2 {
+		return false
+	}
+	bases := []uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37}
+	for _, p := range bases {
+		if n%p == 0 {
+			return n == p
+		}
+	}
+
+	// n - 1 = d * 2^s, d нечетно
+	d := n - 1
+	s := bits.TrailingZeros64(d)
+	d >>= uint(s)
+
+	for _, a := range bases {
+		x := powMod(a, d, n)
+		if x == 1 || x == n-1 {
+			continue
+		}
+		composite := true
+		for r := 1; r < s; r++ {
+			x = mulMod(x, x, n)
+			if x == n-1 {
+				composite = false
+				break
+			}
+		}
+		if composite {
+			return false
+		}
+	}
+	return true
+}
+
+// pollardRho - нетривиальный делитель составного нечетного n
+// (ρ-метод Полларда с поиском цикла по Флойду: x_i и x_{2i})
+func pollardRho(n uint64) uint64 {
+	for c := uint64(1); ; c++ {
+		f := func(x uint64) uint64 {
+			hi, lo := bits.Mul64(x, x)
+			lo, carry := bits.Add64(lo, c, 0)
+			return bits.Rem64(hi+carry, lo, n)
+		}
+		x, y, d := uint64(2), uint64(2), uint64(1)
+		for d == 1 {
+			x = f(x)
+			y = f(f(y))
+			if x > y {
+				d = gcd(x-y, n)
+			} else {
+				d = gcd(y-x, n)
+			}
+		}
+		if d != n {
+			return d
+		}
+	}
+}
+
+// PrimeFactors - различные простые делители n в порядке возрастания
+func PrimeFactors(n uint64) []uint64 {
+	var factors []uint64
+	var split func(n uint64)
+	split = func(n uint64) {
+		if n == 1 {
+			return
+		}
+		if IsPrime(n) {
+			factors = append(factors, n)
+			return
+		}
+		d := pollardRho(n)
+		split(d)
+		split(n / d)
+	}
+
+	// Малые делители отделяются пробным делением
+	for _, p := range []uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37} {
+		if n%p == 0 {
+			factors = append(factors, p)
+			for n%p == 0 {
+				n /= p
+			}
+		}
+	}
+	split(n)
+
+	slices.Sort(factors)
+	return slices.Compact(factors)
+}
@@ -0,0 +1,368 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// Наибольшая размерность спектрального теста
+const spectralMaxDim = 8
+
+// hermiteGammaPow - степени t констант Эрмита γ_t^t для t = 1..8:
+// наибольшая плотность решетчатой упаковки шаров в размерности t
+var hermiteGammaPow = []float64{1, 4.0 / 3, 2, 4, 8, 64.0 / 3, 64, 256}
+
+// SpectralResult - результат спектрального теста в размерности t
+type SpectralResult struct {
+	Dim      int        // Размерность t
+	Nu2      *big.Int   // ν_t² - квадрат длины кратчайшего вектора двойственной решетки
+	Nu       float64    // ν_t
+	Distance float64    // 1/ν_t - наибольшее расстояние между соседними гиперплоскостями
+	Mu       float64    // μ_t = π^(t/2) ν_t^t / ((t/2)! m) - показатель Кнута
+	Merit    float64    // ν_t / (γ_t^(1/2) m^(1/t)) - нормированный показатель из (0, 1]
+	Vector   []*big.Int // Кратчайший вектор s: s_1 + a s_2 + ... + a^(t-1) s_t ≡ 0 (mod m)
+}
+
+// SpectralTest - спектральный тест Кнута (т. 2, разд. 3.3.4) для множителя a
+// и модуля m (m == 0 означает 2^64) в размерностях 2..maxDim. ν_t - длина
+// кратчайшего ненулевого вектора двойственной решетки, найденная
+// LLL-редукцией базиса и последующим перебором Финке - Поста.
+// Для мультипликативных генераторов с m = 2^e Кнут рекомендует
+// проверять модуль m/4.
+func SpectralTest(a, m uint64, maxDim int) ([]SpectralResult, error) {
+	if maxDim < 2 || maxDim > spectralMaxDim {
+		return nil, fmt.Errorf("размерность должна быть от 2 до %d", spectralMaxDim)
+	}
+	if m != 0 && a >= m {
+		return nil, fmt.Errorf("множитель a=%d должен быть меньше модуля m=%d", a, m)
+	}
+	if a == 0 {
+		return nil, errors.New("множитель a должен быть отличен от нуля")
+	}
+	bigM := new(big.Int).SetUint64(m)
+	if m == 0 {
+		bigM.Lsh(big.NewInt(1), 64)
+	}
+	bigA := new(big.Int).SetUint64(a)
+	mf, _ := new(big.Float).SetInt(bigM).Float64()
+
+	results := make([]SpectralResult, 0, maxDim-1)
+	for t := 2; t <= maxDim; t++ {
+		// Базис двойственной решетки: (m, 0, ..., 0) и (-a^(j-1) mod m, e_j)
+		basis := make([][]*big.Int, t)
+		power := big.NewInt(1)
+		for j := range basis {
+			basis[j] = make([]*big.Int, t)
+			for k := range basis[j] {
+				basis[j][k] = new(big.Int)
+			}
+			if j == 0 {
+				basis[0][0].Set(bigM)
+				continue
+			}
+			power.Mul(power, bigA).Mod(power, bigM)
+			basis[j][0].Sub(bigM, power)
+			basis[j][j].SetInt64(1)
+		}
+
+		lllReduce(basis)
+		vec, nu2 := shortestVector(basis)
+
+		nu2f, _ := new(big.Float).SetInt(nu2).Float64()
+		nu := math.Sqrt(nu2f)
+		ft := float64(t)
+		lg, _ := math.Lgamma(ft/2 + 1)
+		logMu := ft/2*math.Log(math.Pi) + ft*math.Log(nu) - lg - math.Log(mf)
+		gamma := math.Pow(hermiteGammaPow[t-1], 1/ft)
+		results = append(results, SpectralResult{
+			Dim:      t,
+			Nu2:      nu2,
+			Nu:       nu,
+			Distance: 1 / nu,
+			Mu:       math.Exp(logMu),
+			Merit:    nu / (math.Sqrt(gamma) * math.Pow(mf, 1/ft)),
+			Vector:   vec,
+		})
+	}
+	return results, nil
+}
+
+// SpectralTest - спектральный тест для параметров генератора
+func (g *LCG) SpectralTest(maxDim int) ([]SpectralResult, error) {
+	return SpectralTest(g.A, g.M, maxDim)
+}
+
+// dotInt - скалярное произведение целочисленных векторов
+func dotInt(u, v []*big.Int) *big.Int {
+	sum, prod := new(big.Int), new(big.Int)
+	for i := range u {
+		sum.Add(sum, prod.Mul(u[i], v[i]))
+	}
+	return sum
+}
+
+// gramSchmidtRat - точная ортогонализация Грама - Шмидта: коэффициенты
+// mu[i][j] = <b_i, b*_j>/<b*_j, b*_j> и квадраты норм <b*_i, b*_i>
+func gramSchmidtRat(b [][]*big.Int) (mu [][]*big.Rat, norms []*big.Rat) {
+	n := len(b)
+	star := make([][]*big.Rat, n)
+	mu = make([][]*big.Rat, n)
+	norms = make([]*big.Rat, n)
+	for i := range b {
+		star[i] = make([]*big.Rat, len(b[i]))
+		for k := range b[i] {
+			star[i][k] = new(big.Rat).SetInt(b[i][k])
+		}
+		mu[i] = make([]*big.Rat, n)
+		for j := 0; j < i; j++ {
+			dot := new(big.Rat)
+			for k := range b[i] {
+				dot.Add(dot, new(big.Rat).Mul(new(big.Rat).SetInt(b[i][k]), star[j][k]))
+			}
+			mu[i][j] = dot.Quo(dot, norms[j])
+			for k := range star[i] {
+				star[i][k].Sub(star[i][k], new(big.Rat).Mul(mu[i][j], star[j][k]))
+			}
+		}
+		norms[i] = new(big.Rat)
+		for _, x := range star[i] {
+			norms[i].Add(norms[i], new(big.Rat).Mul(x, x))
+		}
+	}
+	return mu, norms
+}
+
+// roundRat - ближайшее к x целое
+func roundRat(x *big.Rat) *big.Int {
+	// floor((2*num + den) / (2*den)); Div - евклидово деление, при
+	// положительном делителе совпадающее с округлением вниз
+	num := new(big.Int).Lsh(x.Num(), 1)
+	num.Add(num, x.Denom())
+	return num.Div(num, new(big.Int).Lsh(x.Denom(), 1))
+}
+
+// lllReduce - LLL-редукция базиса (Ленстра, Ленстра, Ловас) с δ = 3/4
+// в точной рациональной арифметике
+func lllReduce(b [][]*big.Int) {
+	delta := big.NewRat(3, 4)
+	half := big.NewRat(1, 2)
+	mu, norms := gramSchmidtRat(b)
+	for k := 1; k < len(b); {
+		// Уменьшение размера: |mu[k][j]| <= 1/2
+		for j := k - 1; j >= 0; j-- {
+			if new(big.Rat).Abs(mu[k][j]).Cmp(half) <= 0 {
+				continue
+			}
+			q := roundRat(mu[k][j])
+			for i := range b[k] {
+				b[k][i].Sub(b[k][i], new(big.Int).Mul(q, b[j][i]))
+			}
+			qr := new(big.Rat).SetInt(q)
+			for i := 0; i < j; i++ {
+				mu[k][i].Sub(mu[k][i], new(big.Rat).Mul(qr, mu[j][i]))
+			}
+			mu[k][j].Sub(mu[k][j], qr)
+		}
+		// Условие Ловаса
+		bound := new(big.Rat).Sub(delta, new(big.Rat).Mul(mu[k][k-1], mu[k][k-1]))
+		if norms[k].Cmp(bound.Mul(bound, norms[k-1])) >= 0 {
+			k++
+			continue
+		}
+		b[k], b[k-1] = b[k-1], b[k]
+		mu, norms = gramSchmidtRat(b)
+		k = max(k-1, 1)
+	}
+}
+
+// shortestVector - кратчайший ненулевой вектор решетки с LLL-редуцированным
+// базисом b. Перебор ведется в арифметике с плавающей точкой с небольшим
+// запасом, а длины кандидатов сравниваются точно.
+func shortestVector(b [][]*big.Int) ([]*big.Int, *big.Int) {
+	n := len(b)
+	// Ортогонализация в float64 (после редукции элементы базиса невелики)
+	bf := make([][]float64, n)
+	for i := range b {
+		bf[i] = make([]float64, len(b[i]))
+		for k := range b[i] {
+			bf[i][k], _ = new(big.Float).SetInt(b[i][k]).Float64()
+		}
+	}
+	star := make([][]float64, n)
+	mu := make([][]float64, n)
+	norms := make([]float64, n)
+	for i := range bf {
+		star[i] = append([]float64(nil), bf[i]...)
+		mu[i] = make([]float64, n)
+		for j := 0; j < i; j++ {
+			var dot float64
+			for k := range bf[i] {
+				dot += bf[i][k] * star[j][k]
+			}
+			mu[i][j] = dot / norms[j]
+			for k := range star[i] {
+				star[i][k] -= mu[i][j] * star[j][k]
+			}
+		}
+		for _, x := range star[i] {
+			norms[i] += x * x
+		}
+	}
+
+	// Начальная оценка - кратчайший вектор базиса
+	best, bestNorm := b[0], dotInt(b[0], b[0])
+	for _, v := range b[1:] {
+		if norm := dotInt(v, v); norm.Cmp(bestNorm) < 0 {
+			best, bestNorm = v, norm
+		}
+	}
+	boundOf := func(norm *big.Int) float64 {
+		f, _ := new(big.Float).SetInt(norm).Float64()
+		return f * (1 + 1e-9)
+	}
+	bound := boundOf(bestNorm)
+
+	x := make([]int64, n)
+	v := make([]*big.Int, len(b[0]))
+	var search func(i int, partial float64)
+	search = func(i int, partial float64) {
+		c := 0.0
+		for j := i + 1; j < n; j++ {
+			c -= mu[j][i] * float64(x[j])
+		}
+		r := math.Sqrt(math.Max(bound-partial, 0) / norms[i])
+		for xi := int64(math.Ceil(c - r)); xi <= int64(math.Floor(c+r)); xi++ {
+			d := float64(xi) - c
+			p := partial + d*d*norms[i]
+			if p > bound {
+				continue
+			}
+			x[i] = xi
+			if i > 0 {
+				search(i-1, p)
+				continue
+			}
+			zero := true
+			for k := range v {
+				v[k] = new(big.Int)
+				for j := range b {
+					v[k].Add(v[k], new(big.Int).Mul(big.NewInt(x[j]), b[j][k]))
+				}
+				zero = zero && v[k].Sign() == 0
+			}
+			if norm := dotInt(v, v); !zero && norm.Cmp(bestNorm) < 0 {
+				best, bestNorm = append([]*big.Int(nil), v...), norm
+				bound = boundOf(bestNorm)
+			}
+		}
+		x[i] = 0
+	}
+	search(n-1, 0)
+	return best, bestNorm
+}
+
+// PrintSpectralTest - таблица результатов спектрального теста
+func PrintSpectralTest(name string, results []SpectralResult) {
+	fmt.Printf("Спектральный тест для %s:\n", name)
+	fmt.Printf("%3s %16s %14s %12s %8s   %s\n", "t", "ν_t", "1/ν_t", "μ_t", "S_t", "Кратчайший вектор")
+	for _, r := range results {
+		fmt.Printf("%3d %16.2f %14.6g %12.4g %8.4f   %v\n", r.Dim, r.Nu, r.Distance, r.Mu, r.Merit, r.Vector)
+	}
+}
+
+// SaveLatticePlot2D - точки (u_n, u_{n+1}) из перекрывающихся пар
+// последовательности u; у ЛКГ они лежат на семействах параллельных прямых
+func SaveLatticePlot2D(u []float64, title, filename string) error {
+	pts := make(plotter.XYs, 0, len(u))
+	for i := 0; i+1 < len(u); i++ {
+		pts = append(pts, plotter.XY{X: u[i], Y: u[i+1]})
+	}
+
+	p := plot.New()
+	p.Title.Text = title
+	p.X.Label.Text = "u_n"
+	p.Y.Label.Text = "u_{n+1}"
+	p.X.Min, p.X.Max, p.Y.Min, p.Y.Max = 0, 1, 0, 1
+
+	scatter, err := plotter.NewScatter(pts)
+	if err != nil {
+		return err
+	}
+	scatter.GlyphStyle.Color = color{0, 0, 255}
+	scatter.GlyphStyle.Radius = vg.Points(1)
+	p.Add(scatter)
+
+	return p.Save(8*vg.Inch, 8*vg.Inch, filename)
+}
+
+// SaveLatticePlot3D - тройки (u_n, u_{n+1}, u_{n+2}) в ортогональной
+// проекции вдоль направления, перпендикулярного normal. Плоскости
+// s·x = const с нормалью normal видны при этом с ребра, как
+// параллельные прямые. Ребра единичного куба рисуются для ориентации.
+func SaveLatticePlot3D(u []float64, normal [3]float64, title, filename string) error {
+	unit := func(v [3]float64) [3]float64 {
+		l := math.Sqrt(v[0]*v[0] + v[1]*v[1] + v[2]*v[2])
+		return [3]float64{v[0] / l, v[1] / l, v[2] / l}
+	}
+	cross := func(a, b [3]float64) [3]float64 {
+		return [3]float64{a[1]*b[2] - a[2]*b[1], a[2]*b[0] - a[0]*b[2], a[0]*b[1] - a[1]*b[0]}
+	}
+	dot := func(a, b [3]float64) float64 { return a[0]*b[0] + a[1]*b[1] + a[2]*b[2] }
+
+	// Ось абсцисс - нормаль к плоскостям, ось ординат - перпендикуляр к ней
+	// (через координатную ось, наименее близкую к нормали)
+	e1 := unit(normal)
+	k := 0
+	for j := 1; j < 3; j++ {
+		if math.Abs(e1[j]) < math.Abs(e1[k]) {
+			k = j
+		}
+	}
+	var axis [3]float64
+	axis[k] = 1
+	e2 := unit(cross(cross(e1, axis), e1))
+	project := func(q [3]float64) plotter.XY { return plotter.XY{X: dot(q, e1), Y: dot(q, e2)} }
+
+	p := plot.New()
+	p.Title.Text = title
+	p.X.Label.Text = fmt.Sprintf("Проекция на нормаль (%.0f, %.0f, %.0f)", normal[0], normal[1], normal[2])
+	p.Y.Label.Text = "Проекция на перпендикуляр"
+
+	for i := 0; i < 8; i++ {
+		for k := 0; k < 3; k++ {
+			if i&(1<<k) != 0 {
+				continue
+			}
+			from := [3]float64{float64(i & 1), float64(i >> 1 & 1), float64(i >> 2 & 1)}
+			to := from
+			to[k] = 1
+			edge, err := plotter.NewLine(plotter.XYs{project(from), project(to)})
+			if err != nil {
+				return err
+			}
+			edge.Color = color{160, 160, 160}
+			edge.Width = vg.Points(0.5)
+			p.Add(edge)
+		}
+	}
+
+	pts := make(plotter.XYs, 0, len(u))
+	for i := 0; i+2 < len(u); i++ {
+		pts = append(pts, project([3]float64{u[i], u[i+1], u[i+2]}))
+	}
+	scatter, err := plotter.NewScatter(pts)
+	if err != nil {
+		return err
+	}
+	scatter.GlyphStyle.Color = color{255, 0, 0}
+	scatter.GlyphStyle.Radius = vg.Points(1)
+	p.Add(scatter)
+
+	return p.Save(8*vg.Inch, 8*vg.Inch, filename)
+}
@@ -4,7 +4,10 @@ go 1.24.0
 
 toolchain go1.24.5
 
-require gonum.org/v1/plot v0.16.0
+require (
+	gonum.org/v1/plot v0.16.0
+	labs/stats v0.0.0
+)
 
 require (
 	codeberg.org/go-fonts/liberation v0.5.0 // indirect
@@ -12,9 +15,9 @@ require (
 	codeberg.org/go-pdf/fpdf v0.11.1 // indirect
 	git.sr.ht/~sbinet/gg v0.7.0 // indirect
 	github.com/ajstarks/svgo v0.0.0-20211024235047-1546f124cd8b // indirect
-	github.com/campoy/embedmd v1.0.0 // indirect
 	github.com/golang/freetype v0.0.0-20170609003504-e2365dfdc4a0 // indirect
-	github.com/pmezard/go-difflib v1.0.0 // indirect
 	golang.org/x/image v0.34.0 // indirect
 	golang.org/x/text v0.32.0 // indirect
 )
+
+replace labs/stats => ../stats
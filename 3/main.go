@@ -5,6 +5,7 @@ import (
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
+	"labs/stats"
 	"math"
 	"math/rand"
 	"time"
@@ -143,30 +144,7 @@ func BuildHistogram(data []float64, bins int, a, b float64, filename, title stri
 
 // Функция для расчета экспериментальной плотности вероятности
 func CalculateExperimentalPDF(data []float64, bins int, a, b float64) []float64 {
-	// Инициализируем массив для подсчета
-	counts := make([]int, bins)
-	binWidth := (b - a) / float64(bins)
-
-	// Подсчитываем попадания в бины
-	for _, value := range data {
-		if value >= a && value <= b {
-			binIndex := int((value - a) / binWidth)
-			if binIndex >= bins {
-				binIndex = bins - 1
-			}
-			counts[binIndex]++
-		}
-	}
-
-	// Преобразуем в плотность вероятности
-	pdf := make([]float64, bins)
-	total := float64(len(data))
-
-	for i := 0; i < bins; i++ {
-		pdf[i] = float64(counts[i]) / (total * binWidth)
-	}
-
-	return pdf
+	return stats.NewUniformHistogram(bins, a, b).FillAll(data).Densities()
 }
 
 // Функция для расчета теоретической плотности вероятности в центрах бинов
@@ -204,7 +182,8 @@ func main() {
 	rand.Seed(time.Now().UnixNano())
 
 	fmt.Println("Практическая работа №3")
-	fmt.Println("Моделирование нормального закона распределения\n")
+	fmt.Println("Моделирование нормального закона распределения")
+	fmt.Println()
 
 	// Задание 1: Построение графиков плотности вероятности
 	fmt.Println("=== ЗАДАНИЕ 1 ===")
@@ -527,6 +506,25 @@ func main() {
 		fmt.Println("График сравнения сохранен: comparison_theory_vs_exp.png")
 	}
 
+	// Критерий Колмогорова - Смирнова: согласие сгенерированных выборок
+	// с теоретической функцией распределения
+	fmt.Println("\n=== КРИТЕРИЙ КОЛМОГОРОВА - СМИРНОВА ===")
+
+	const alpha = 0.05
+	normalCDF := func(x float64) float64 {
+		return NormalCDF(x, mean, sigma)
+	}
+	for _, N := range []int{1000, 10000, 100000} {
+		data := GenerateNormalInverseCDF(mean, sigma, a, b, intervals, N)
+		fmt.Printf("N=%d: %v\n", N, stats.KSTest(data, normalCDF, alpha))
+	}
+
+	ksData := GenerateNormalInverseCDF(mean, sigma, a, b, intervals, 1000)
+	if err := stats.SaveECDFPlot(ksData, normalCDF, alpha, "Эмпирическая функция распределения (M=10, σ=2, N=1000)", "ks_ecdf_normal.png"); err != nil {
+		fmt.Printf("Ошибка сохранения графика: %v\n", err)
+	} else {
+		fmt.Println("График эмпирической функции распределения сохранен: ks_ecdf_normal.png")
+	}
 	fmt.Println("\n=== ПРАКТИЧЕСКАЯ РАБОТА ЗАВЕРШЕНА ===")
 	fmt.Println("Созданы файлы:")
 	fmt.Println("1. task1_normal_pdf.png - плотности вероятности для разных параметров")
@@ -534,6 +532,7 @@ func main() {
 	fmt.Println("3. task4_histogram_N*.png - гистограммы для разных N")
 	fmt.Println("4. task5_rmse_vs_N.png - зависимость RMSE от N")
 	fmt.Println("5. comparison_theory_vs_exp.png - сравнение теоретического и экспериментального")
+	fmt.Println("6. ks_ecdf_normal.png - эмпирическая функция распределения с полосой Колмогорова")
 }
 
 // Вспомогательная структура для цвета
@@ -16,6 +16,19 @@ func MultiplicativeRNG(a, b, m, x0 int64) func() float64 {
 	}
 }
 
+// Функция для пропуска k чисел мультипликативного генератора: x_k вычисляется
+// возведением отображения x -> a*x + b в степень k за O(log k) умножений
+func MultiplicativeJump(a, b, m, x0, k int64) int64 {
+	A, B := int64(1), int64(0)
+	for ; k > 0; k >>= 1 {
+		if k&1 == 1 {
+			A, B = a*A%m, (a*B+b)%m
+		}
+		a, b = a*a%m, (a*b+b)%m
+	}
+	return (A*x0 + B) % m
+}
+
 // Функция для генерации равномерно распределенных чисел в интервале [min, max]
 func UniformDistribution(generator func() float64, min, max float64) float64 {
 	return min + (max-min)*generator()
@@ -155,7 +168,8 @@ func (qs *QueueingSystem) GetBufferProbabilities() map[int]float64 {
 
 func main() {
 	fmt.Println("Практическая работа №4")
-	fmt.Println("Имитационное моделирование вычислительных систем\n")
+	fmt.Println("Имитационное моделирование вычислительных систем")
+	fmt.Println()
 
 	rand.Seed(time.Now().UnixNano())
 
@@ -178,6 +192,15 @@ func main() {
 	randTZ := MultiplicativeRNG(a_TZ, b, M, x0)
 	randTS := MultiplicativeRNG(a_TS, b, M, x0)
 
+	// Проверка пропуска: x_n, вычисленное скачком, совпадает с n-м числом генератора
+	check := MultiplicativeRNG(a_TZ, b, M, x0)
+	var last float64
+	for i := 0; i < numRequests; i++ {
+		last = check()
+	}
+	fmt.Printf("x_%d скачком за O(log k): %d, последовательно: %.0f\n",
+		numRequests, MultiplicativeJump(a_TZ, b, M, x0, int64(numRequests)), last*float64(M))
+
 	// Параметры распределений
 	TZmin := 4.0  // сек
 	TZmax := 12.0 // сек
@@ -222,7 +245,8 @@ func main() {
 	for i := numRequests - 5; i < numRequests && i >= 0; i++ {
 		fmt.Printf("%.2f ", arrivalTimes[i])
 	}
-	fmt.Println("\n")
+	fmt.Println()
+	fmt.Println()
 
 	// ========== ЗАДАНИЕ 3 ==========
 	fmt.Println("=== ЗАДАНИЕ 3 ===")
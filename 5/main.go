@@ -6,6 +6,7 @@ import (
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
+	"labs/stats"
 	"math"
 	"math/rand"
 	"time"
@@ -21,6 +22,14 @@ func ExponentialPDF(x, lambda float64) float64 {
 	return lambda * math.Exp(-lambda*x)
 }
 
+// ExponentialCDF - функция распределения экспоненциального закона
+func ExponentialCDF(x, lambda float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return 1 - math.Exp(-lambda*x)
+}
+
 // ========== ЗАДАНИЕ 2 ==========
 
 // InverseExponential - функция обратного преобразования для экспоненциального распределения
@@ -49,32 +58,8 @@ func GenerateExponentialDistribution(lambda float64, n int) []float64 {
 
 // CalculateHistogram - вычисление гистограммы
 func CalculateHistogram(data []float64, bins int, maxVal float64) ([]float64, []float64) {
-	// Инициализируем массив для подсчета
-	counts := make([]int, bins)
-	binWidth := maxVal / float64(bins)
-
-	// Подсчитываем попадания в бины
-	for _, value := range data {
-		if value >= 0 && value < maxVal {
-			binIndex := int(value / binWidth)
-			if binIndex >= bins {
-				binIndex = bins - 1
-			}
-			counts[binIndex]++
-		}
-	}
-
-	// Преобразуем в относительные частоты (плотность вероятности)
-	frequencies := make([]float64, bins)
-	binCenters := make([]float64, bins)
-	total := float64(len(data))
-
-	for i := 0; i < bins; i++ {
-		binCenters[i] = (float64(i) + 0.5) * binWidth
-		frequencies[i] = float64(counts[i]) / (total * binWidth)
-	}
-
-	return binCenters, frequencies
+	h := stats.NewUniformHistogram(bins, 0, maxVal).FillAll(data)
+	return h.Centers(), h.Densities()
 }
 
 // CalculateRMSE - расчет среднеквадратического отклонения между экспериментальным и теоретическим распределением
@@ -116,7 +101,8 @@ func (c color) RGBA() (r, g, b, a uint32) {
 
 func main() {
 	fmt.Println("Практическая работа №5")
-	fmt.Println("Моделирование экспоненциального закона распределения\n")
+	fmt.Println("Моделирование экспоненциального закона распределения")
+	fmt.Println()
 
 	// Инициализация генератора случайных чисел
 	rand.Seed(time.Now().UnixNano())
@@ -448,6 +434,25 @@ func main() {
 		fmt.Printf("  Относительная ошибка: %.2f%%\n", math.Abs(mean-theoryMean)/theoryMean*100)
 	}
 
+	// Критерий Колмогорова - Смирнова: согласие сгенерированных выборок
+	// с теоретической функцией распределения
+	fmt.Println("\n=== КРИТЕРИЙ КОЛМОГОРОВА - СМИРНОВА ===")
+
+	const alpha = 0.05
+	exponentialCDF := func(x float64) float64 {
+		return ExponentialCDF(x, lambda1)
+	}
+	for _, N := range []int{1000, 10000, 100000} {
+		data := GenerateExponentialDistribution(lambda1, N)
+		fmt.Printf("λ=%.1f, N=%d: %v\n", lambda1, N, stats.KSTest(data, exponentialCDF, alpha))
+	}
+
+	ksData := GenerateExponentialDistribution(lambda1, 1000)
+	if err := stats.SaveECDFPlot(ksData, exponentialCDF, alpha, "Эмпирическая функция распределения (λ=1.5, N=1000)", "ks_ecdf_exponential.png"); err != nil {
+		fmt.Printf("Ошибка сохранения графика: %v\n", err)
+	} else {
+		fmt.Println("График эмпирической функции распределения сохранен: ks_ecdf_exponential.png")
+	}
 	fmt.Println("\n=== ПРАКТИЧЕСКАЯ РАБОТА ЗАВЕРШЕНА ===")
 	fmt.Println("Созданы файлы:")
 	fmt.Println("1. task1_exponential_pdf.png - плотности вероятности для разных λ")
@@ -455,6 +460,7 @@ func main() {
 	fmt.Println("3. task4_histogram_N*.png - гистограммы для разных N")
 	fmt.Println("4. task4_histograms_comparison.png - сравнение гистограмм")
 	fmt.Println("5. task4_rmse_vs_n.png - зависимость RMSE от N")
+	fmt.Println("6. ks_ecdf_exponential.png - эмпирическая функция распределения с полосой Колмогорова")
 }
 
 // Функция для сохранения отдельных гистограмм
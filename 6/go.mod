@@ -4,7 +4,10 @@ go 1.24.0
 
 toolchain go1.24.5
 
-require gonum.org/v1/plot v0.16.0
+require (
+	gonum.org/v1/plot v0.16.0
+	labs/stats v0.0.0
+)
 
 require (
 	codeberg.org/go-fonts/liberation v0.5.0 // indirect
@@ -16,3 +19,5 @@ require (
 	golang.org/x/image v0.34.0 // indirect
 	golang.org/x/text v0.32.0 // indirect
 )
+
+replace labs/stats => ../stats
@@ -6,6 +6,7 @@ import (
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
+	"labs/stats"
 	"math"
 	"math/rand"
 	"time"
@@ -37,6 +38,14 @@ func WeibullPDF(x, lambda, k float64) float64 {
 	return term1 * term2 * term3
 }
 
+// WeibullCDF - функция распределения Вейбулла: F(x) = 1 - exp(-(x/λ)^k)
+func WeibullCDF(x, lambda, k float64) float64 {
+	if x < 0 || lambda <= 0 || k <= 0 {
+		return 0
+	}
+	return 1 - math.Exp(-math.Pow(x/lambda, k))
+}
+
 // ========== ЗАДАНИЕ 2 ==========
 
 // InverseWeibull - функция обратного преобразования для распределения Вейбулла
@@ -66,32 +75,8 @@ func GenerateWeibullDistribution(lambda, k float64, n int) []float64 {
 
 // CalculateWeibullHistogram - вычисление гистограммы для распределения Вейбулла
 func CalculateWeibullHistogram(data []float64, bins int, maxVal float64) ([]float64, []float64) {
-	// Инициализируем массив для подсчета
-	counts := make([]int, bins)
-	binWidth := maxVal / float64(bins)
-
-	// Подсчитываем попадания в бины
-	for _, value := range data {
-		if value >= 0 && value < maxVal {
-			binIndex := int(value / binWidth)
-			if binIndex >= bins {
-				binIndex = bins - 1
-			}
-			counts[binIndex]++
-		}
-	}
-
-	// Преобразуем в относительные частоты (плотность вероятности)
-	frequencies := make([]float64, bins)
-	binCenters := make([]float64, bins)
-	total := float64(len(data))
-
-	for i := 0; i < bins; i++ {
-		binCenters[i] = (float64(i) + 0.5) * binWidth
-		frequencies[i] = float64(counts[i]) / (total * binWidth)
-	}
-
-	return binCenters, frequencies
+	h := stats.NewUniformHistogram(bins, 0, maxVal).FillAll(data)
+	return h.Centers(), h.Densities()
 }
 
 // CalculateWeibullRMSE - расчет RMSE между экспериментальным и теоретическим распределением Вейбулла
@@ -156,7 +141,8 @@ func (c color) RGBA() (r, g, b, a uint32) {
 
 func main() {
 	fmt.Println("Практическая работа №6")
-	fmt.Println("Моделирование закона распределения Вейбулла\n")
+	fmt.Println("Моделирование закона распределения Вейбулла")
+	fmt.Println()
 
 	// Инициализация генератора случайных чисел
 	rand.Seed(time.Now().UnixNano())
@@ -548,6 +534,25 @@ func main() {
 			testLambda, k, mode, median)
 	}
 
+	// Критерий Колмогорова - Смирнова: согласие сгенерированных выборок
+	// с теоретической функцией распределения
+	fmt.Println("\n=== КРИТЕРИЙ КОЛМОГОРОВА - СМИРНОВА ===")
+
+	const alpha = 0.05
+	weibullCDF := func(x float64) float64 {
+		return WeibullCDF(x, 2, 2)
+	}
+	for _, N := range []int{1000, 10000, 100000} {
+		data := GenerateWeibullDistribution(2, 2, N)
+		fmt.Printf("λ=2, k=2, N=%d: %v\n", N, stats.KSTest(data, weibullCDF, alpha))
+	}
+
+	ksData := GenerateWeibullDistribution(2, 2, 1000)
+	if err := stats.SaveECDFPlot(ksData, weibullCDF, alpha, "Эмпирическая функция распределения (λ=2, k=2, N=1000)", "ks_ecdf_weibull.png"); err != nil {
+		fmt.Printf("Ошибка сохранения графика: %v\n", err)
+	} else {
+		fmt.Println("График эмпирической функции распределения сохранен: ks_ecdf_weibull.png")
+	}
 	fmt.Println("\n=== ПРАКТИЧЕСКАЯ РАБОТА ЗАВЕРШЕНА ===")
 	fmt.Println("Созданы файлы:")
 	fmt.Println("1. task1_weibull_pdf.png - плотности вероятности для разных параметров")
@@ -555,6 +560,7 @@ func main() {
 	fmt.Println("3. task4_weibull_histogram_N*.png - гистограммы для разных N")
 	fmt.Println("4. task4_weibull_histograms.png - сравнение гистограмм")
 	fmt.Println("5. task4_weibull_rmse_vs_n.png - зависимость RMSE от N")
+	fmt.Println("6. ks_ecdf_weibull.png - эмпирическая функция распределения с полосой Колмогорова")
 }
 
 // Функция для сохранения отдельных гистограмм
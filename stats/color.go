@@ -0,0 +1,19 @@
+package stats
+
+// Вспомогательная структура для цвета
+type color struct {
+	R, G, B uint8
+}
+
+// Метод для преобразования цвета в RGBA
+func (c color) RGBA() (r, g, b, a uint32) {
+	r = uint32(c.R)
+	r |= r << 8
+	g = uint32(c.G)
+	g |= g << 8
+	b = uint32(c.B)
+	b |= b << 8
+	a = uint32(255)
+	a |= a << 8
+	return
+}
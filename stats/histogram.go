@@ -0,0 +1,181 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// Histogram - гистограмма с произвольными границами интервалов.
+// Интервалы полуоткрытые [e_i, e_{i+1}), последний - закрытый
+// [e_{n-1}, e_n], поэтому значение, равное правой границе, учитывается.
+// Значения левее первой границы попадают в Underflow, правее последней
+// (и NaN) - в Overflow.
+type Histogram struct {
+	Edges     []float64 // Границы интервалов по возрастанию
+	Weights   []float64 // Суммарный вес значений в каждом интервале
+	Underflow float64   // Вес значений левее первой границы
+	Overflow  float64   // Вес значений правее последней границы
+	Entries   int       // Количество добавленных значений
+
+	uniform bool // Интервалы равной ширины: номер интервала вычисляется без поиска
+}
+
+// NewHistogram - гистограмма с границами edges (не менее двух,
+// строго по возрастанию)
+func NewHistogram(edges []float64) (*Histogram, error) {
+	if len(edges) < 2 {
+		return nil, errors.New("нужны как минимум две границы интервалов")
+	}
+	for i := 1; i < len(edges); i++ {
+		if !(edges[i] > edges[i-1]) {
+			return nil, fmt.Errorf("границы интервалов должны возрастать: %g, %g", edges[i-1], edges[i])
+		}
+	}
+	return &Histogram{Edges: slices.Clone(edges), Weights: make([]float64, len(edges)-1)}, nil
+}
+
+// NewUniformHistogram - гистограмма из bins интервалов равной ширины на [a, b].
+// Вызывает панику при bins < 1 или b <= a.
+func NewUniformHistogram(bins int, a, b float64) *Histogram {
+	if bins < 1 || !(b > a) {
+		panic(fmt.Sprintf("некорректные параметры гистограммы: bins=%d, [%g; %g]", bins, a, b))
+	}
+	edges := make([]float64, bins+1)
+	for i := range edges {
+		edges[i] = a + (b-a)*float64(i)/float64(bins)
+	}
+	edges[bins] = b
+	return &Histogram{Edges: edges, Weights: make([]float64, bins), uniform: true}
+}
+
+// Bins - количество интервалов
+func (h *Histogram) Bins() int {
+	return len(h.Weights)
+}
+
+// Find - номер интервала, содержащего x: -1 для значений левее первой
+// границы, Bins() для значений правее последней и NaN
+func (h *Histogram) Find(x float64) int {
+	n := h.Bins()
+	lo, hi := h.Edges[0], h.Edges[n]
+	switch {
+	case x < lo:
+		return -1
+	case x == hi:
+		return n - 1
+	case !(x < hi):
+		return n
+	}
+	if h.uniform {
+		i := int((x - lo) / (hi - lo) * float64(n))
+		// Поправка на ошибки округления вблизи границ
+		for i > 0 && x < h.Edges[i] {
+			i--
+		}
+		for i < n-1 && x >= h.Edges[i+1] {
+			i++
+		}
+		return i
+	}
+	// Последняя граница, не превосходящая x
+	return sort.Search(len(h.Edges), func(i int) bool { return h.Edges[i] > x }) - 1
+}
+
+// Fill - добавление значения x с весом 1
+func (h *Histogram) Fill(x float64) {
+	h.FillWeighted(x, 1)
+}
+
+// FillWeighted - добавление значения x с весом w
+func (h *Histogram) FillWeighted(x, w float64) {
+	h.Entries++
+	switch i := h.Find(x); {
+	case i < 0:
+		h.Underflow += w
+	case i >= h.Bins():
+		h.Overflow += w
+	default:
+		h.Weights[i] += w
+	}
+}
+
+// FillAll - добавление всех значений data с весом 1
+func (h *Histogram) FillAll(data []float64) *Histogram {
+	for _, x := range data {
+		h.Fill(x)
+	}
+	return h
+}
+
+// Merge - добавление содержимого гистограммы other с теми же границами
+func (h *Histogram) Merge(other *Histogram) error {
+	if !slices.Equal(h.Edges, other.Edges) {
+		return errors.New("границы интервалов гистограмм не совпадают")
+	}
+	for i, w := range other.Weights {
+		h.Weights[i] += w
+	}
+	h.Underflow += other.Underflow
+	h.Overflow += other.Overflow
+	h.Entries += other.Entries
+	return nil
+}
+
+// Reset - очистка гистограммы с сохранением границ
+func (h *Histogram) Reset() {
+	clear(h.Weights)
+	h.Underflow, h.Overflow, h.Entries = 0, 0, 0
+}
+
+// Total - суммарный вес, включая значения за пределами интервалов
+func (h *Histogram) Total() float64 {
+	total := h.Underflow + h.Overflow
+	for _, w := range h.Weights {
+		total += w
+	}
+	return total
+}
+
+// Width - ширина интервала i
+func (h *Histogram) Width(i int) float64 {
+	return h.Edges[i+1] - h.Edges[i]
+}
+
+// Centers - середины интервалов
+func (h *Histogram) Centers() []float64 {
+	centers := make([]float64, h.Bins())
+	for i := range centers {
+		centers[i] = (h.Edges[i] + h.Edges[i+1]) / 2
+	}
+	return centers
+}
+
+// Counts - веса (количества попаданий) по интервалам
+func (h *Histogram) Counts() []float64 {
+	return slices.Clone(h.Weights)
+}
+
+// RelFreq - относительные частоты: вес интервала, деленный на суммарный вес
+func (h *Histogram) RelFreq() []float64 {
+	total := h.Total()
+	freq := make([]float64, h.Bins())
+	if total == 0 {
+		return freq
+	}
+	for i, w := range h.Weights {
+		freq[i] = w / total
+	}
+	return freq
+}
+
+// Densities - оценка плотности вероятности: относительная частота,
+// деленная на ширину интервала
+func (h *Histogram) Densities() []float64 {
+	dens := h.RelFreq()
+	for i := range dens {
+		dens[i] /= h.Width(i)
+	}
+	return dens
+}
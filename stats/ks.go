@@ -0,0 +1,279 @@
+// Package stats - общие для лабораторных работ статистические критерии
+// и гистограммы
+package stats
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// Максимальный порядок матрицы 2[n*D]+1 в точном расчете распределения
+// статистики Колмогорова; при n*D >= 151 точное значение не вычисляется
+const ksExactMaxOrder = 301
+
+// KSResult - результат проверки гипотезы критерием Колмогорова - Смирнова
+type KSResult struct {
+	D           float64 // Статистика D = sup |F_n(x) - F(x)|
+	N           int     // Объем выборки
+	At          float64 // Точка, в которой достигается максимальное отклонение
+	AsymptoticP float64 // p-значение по предельному распределению Колмогорова
+	ExactP      float64 // Точное p-значение (Марсалья - Цанг - Ванг), NaN при n*D >= 151 и в дальнем хвосте
+	Alpha       float64 // Уровень значимости
+	Reject      bool    // Гипотеза отвергается
+}
+
+// String - краткий отчет о результате проверки
+func (r KSResult) String() string {
+	verdict := "гипотеза принимается"
+	if r.Reject {
+		verdict = "гипотеза отвергается"
+	}
+	exact := "не рассчитано"
+	if !math.IsNaN(r.ExactP) {
+		exact = fmt.Sprintf("%.4f", r.ExactP)
+	}
+	return fmt.Sprintf("D = %.5f (в точке x = %.4f), p асимпт. = %.4f, p точн. = %s: %s на уровне значимости %g",
+		r.D, r.At, r.AsymptoticP, exact, verdict, r.Alpha)
+}
+
+// KSTest - одновыборочный критерий Колмогорова - Смирнова для
+// непрерывной функции распределения cdf. Решение принимается по точному
+// p-значению, а если оно не рассчитано - по асимптотическому.
+func KSTest(data []float64, cdf func(float64) float64, alpha float64) KSResult {
+	sorted := slices.Clone(data)
+	slices.Sort(sorted)
+	n := len(sorted)
+	res := KSResult{N: n, Alpha: alpha, ExactP: math.NaN()}
+	if n == 0 {
+		return res
+	}
+
+	// Отклонения до и после скачка эмпирической функции в каждой точке
+	for i, x := range sorted {
+		f := cdf(x)
+		if d := float64(i+1)/float64(n) - f; d > res.D {
+			res.D, res.At = d, x
+		}
+		if d := f - float64(i)/float64(n); d > res.D {
+			res.D, res.At = d, x
+		}
+	}
+
+	res.AsymptoticP = KolmogorovQ(math.Sqrt(float64(n)) * res.D)
+	if cdfExact, ok := ksExactCDF(n, res.D); ok {
+		res.ExactP = math.Min(math.Max(1-cdfExact, 0), 1)
+	}
+	p := res.ExactP
+	if math.IsNaN(p) {
+		p = res.AsymptoticP
+	}
+	res.Reject = p < alpha
+	return res
+}
+
+// KolmogorovQ - P(K > t) для предельного распределения Колмогорова:
+// 2 Σ (-1)^(k-1) exp(-2 k^2 t^2)
+func KolmogorovQ(t float64) float64 {
+	if t <= 0 {
+		return 1
+	}
+	// При малых t ряд сходится медленно, используется формула
+	// sqrt(2π)/t Σ exp(-(2k-1)^2 π^2 / (8 t^2))
+	if t < 1 {
+		var sum float64
+		for k := 1; k <= 10; k++ {
+			sum += math.Exp(-float64((2*k-1)*(2*k-1)) * math.Pi * math.Pi / (8 * t * t))
+		}
+		return 1 - math.Sqrt(2*math.Pi)/t*sum
+	}
+	var sum float64
+	sign := 1.0
+	for k := 1; k <= 100; k++ {
+		term := math.Exp(-2 * float64(k*k) * t * t)
+		sum += sign * term
+		if term < 1e-16 {
+			break
+		}
+		sign = -sign
+	}
+	return math.Min(math.Max(2*sum, 0), 1)
+}
+
+// KSCriticalValue - критическое значение статистики D для выборки объема n
+// на уровне значимости alpha (по предельному распределению)
+func KSCriticalValue(n int, alpha float64) float64 {
+	lo, hi := 0.0, 5.0
+	for i := 0; i < 100; i++ {
+		mid := (lo + hi) / 2
+		if KolmogorovQ(mid) > alpha {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2 / math.Sqrt(float64(n))
+}
+
+// ksExactCDF - P(D_n < d) по алгоритму Марсальи - Цанга - Ванга (2003):
+// элемент возведенной в степень n матрицы порядка 2k-1, k = [n*d] + 1.
+// Второе значение равно false, если порядок матрицы слишком велик или d
+// лежит в дальнем хвосте, где авторы вместо точного расчета предлагают
+// приближение; тогда используется асимптотическое p-значение.
+func ksExactCDF(n int, d float64) (float64, bool) {
+	nd := float64(n) * d
+	s := d * d * float64(n)
+	// Дальний хвост: p-значение не больше 0.0011, точный расчет не выполняется
+	if s > 7.24 || (s > 3.76 && n > 99) {
+		return 0, false
+	}
+
+	k := int(nd) + 1
+	m := 2*k - 1
+	if m > ksExactMaxOrder {
+		return 0, false
+	}
+	h := float64(k) - nd
+
+	H := make([]float64, m*m)
+	for i := 0; i < m; i++ {
+		for j := 0; j < m; j++ {
+			if i-j+1 >= 0 {
+				H[i*m+j] = 1
+			}
+		}
+	}
+	for i := 0; i < m; i++ {
+		H[i*m] -= math.Pow(h, float64(i+1))
+		H[(m-1)*m+i] -= math.Pow(h, float64(m-i))
+	}
+	if 2*h-1 > 0 {
+		H[(m-1)*m] += math.Pow(2*h-1, float64(m))
+	}
+	for i := 0; i < m; i++ {
+		for j := 0; j < m; j++ {
+			if i-j+1 > 0 {
+				for g := 1; g <= i-j+1; g++ {
+					H[i*m+j] /= float64(g)
+				}
+			}
+		}
+	}
+
+	Q, eQ := ksMatrixPower(H, m, n)
+	p := Q[(k-1)*m+k-1]
+	for i := 1; i <= n; i++ {
+		p = p * float64(i) / float64(n)
+		if p < 1e-140 {
+			p *= 1e140
+			eQ -= 140
+		}
+	}
+	return p * math.Pow(10, float64(eQ)), true
+}
+
+// ksMatrixPower - A^n с масштабированием: результат равен V * 10^e
+func ksMatrixPower(A []float64, m, n int) (V []float64, e int) {
+	if n == 1 {
+		return slices.Clone(A), 0
+	}
+	V, e = ksMatrixPower(A, m, n/2)
+	B := ksMatrixMultiply(V, V, m)
+	eB := 2 * e
+	if n%2 == 1 {
+		B = ksMatrixMultiply(A, B, m)
+	}
+	if B[(m/2)*m+m/2] > 1e140 {
+		for i := range B {
+			B[i] *= 1e-140
+		}
+		eB += 140
+	}
+	return B, eB
+}
+
+func ksMatrixMultiply(A, B []float64, m int) []float64 {
+	C := make([]float64, m*m)
+	for i := 0; i < m; i++ {
+		for k := 0; k < m; k++ {
+			a := A[i*m+k]
+			if a == 0 {
+				continue
+			}
+			for j := 0; j < m; j++ {
+				C[i*m+j] += a * B[k*m+j]
+			}
+		}
+	}
+	return C
+}
+
+// SaveECDFPlot - график эмпирической и теоретической функций распределения
+// с доверительной полосой Колмогорова F_n(x) ± D_alpha
+func SaveECDFPlot(data []float64, cdf func(float64) float64, alpha float64, title, filename string) error {
+	sorted := slices.Clone(data)
+	slices.Sort(sorted)
+	n := len(sorted)
+	if n == 0 {
+		return fmt.Errorf("пустая выборка")
+	}
+	eps := KSCriticalValue(n, alpha)
+
+	// Для больших выборок ступенчатая функция строится по не более
+	// чем 2000 точкам
+	step := max(n/2000, 1)
+	var ecdf, lower, upper, theory plotter.XYs
+	for i := 0; i < n; i += step {
+		x := sorted[i]
+		before, after := float64(i)/float64(n), float64(i+1)/float64(n)
+		ecdf = append(ecdf, plotter.XY{X: x, Y: before}, plotter.XY{X: x, Y: after})
+		lower = append(lower, plotter.XY{X: x, Y: math.Max(after-eps, 0)})
+		upper = append(upper, plotter.XY{X: x, Y: math.Min(after+eps, 1)})
+		theory = append(theory, plotter.XY{X: x, Y: cdf(x)})
+	}
+
+	p := plot.New()
+	p.Title.Text = title
+	p.X.Label.Text = "x"
+	p.Y.Label.Text = "F(x)"
+	p.Legend.Top = true
+	p.Legend.Left = true
+
+	empLine, err := plotter.NewLine(ecdf)
+	if err != nil {
+		return err
+	}
+	empLine.Color = color{255, 0, 0}
+	empLine.Width = vg.Points(1)
+	p.Add(empLine)
+	p.Legend.Add(fmt.Sprintf("Эмпирическая (N=%d)", n), empLine)
+
+	theoryLine, err := plotter.NewLine(theory)
+	if err != nil {
+		return err
+	}
+	theoryLine.Color = color{0, 0, 255}
+	theoryLine.Width = vg.Points(2)
+	p.Add(theoryLine)
+	p.Legend.Add("Теоретическая", theoryLine)
+
+	for i, band := range []plotter.XYs{lower, upper} {
+		bandLine, err := plotter.NewLine(band)
+		if err != nil {
+			return err
+		}
+		bandLine.Color = color{0, 128, 0}
+		bandLine.Width = vg.Points(1)
+		bandLine.Dashes = []vg.Length{vg.Points(3), vg.Points(3)}
+		p.Add(bandLine)
+		if i == 0 {
+			p.Legend.Add(fmt.Sprintf("Полоса ±%.4f (α=%g)", eps, alpha), bandLine)
+		}
+	}
+
+	return p.Save(10*vg.Inch, 6*vg.Inch, filename)
+}